@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// C40 solution
+func C40() {
+	fmt.Println("---------------------- c40 ------------------------")
+	const msg = "Cause we're having a good time, having a good time"
+
+	var crypts []*big.Int
+	var keys []*rsa.PublicKey
+	for i := 0; i < 3; i++ {
+		priv, err := rsa.GenerateKey(1024, 3)
+		if err != nil {
+			log.Fatalf("failed to generate RSA key: %v", err)
+		}
+		keys = append(keys, &priv.PublicKey)
+		crypts = append(crypts, priv.Encrypt(rsa.OS2IP([]byte(msg))))
+	}
+
+	plain, err := BroadcastAttack(crypts, keys)
+	if err != nil {
+		log.Fatalf("broadcast attack failed: %v", err)
+	}
+	fmt.Printf("Recovered plaintext: %q\n", plain.Bytes())
+}
+
+// BroadcastAttack recovers a message that has been encrypted under
+// e=3 for three different public keys (Håstad's broadcast attack).
+//
+// Combining the three cyphertexts with the CRT gives m^3 mod N1*N2*N3.
+// Since m is smaller than each modulus, m^3 is smaller than their product,
+// so the result is exactly m^3 and a plain integer cube root yields m.
+func BroadcastAttack(crypts []*big.Int, keys []*rsa.PublicKey) (*big.Int, error) {
+	if len(crypts) != 3 || len(keys) != 3 {
+		return nil, fmt.Errorf("need exactly 3 cyphertexts and keys, got %v and %v", len(crypts), len(keys))
+	}
+	moduli := make([]*big.Int, len(keys))
+	for i, k := range keys {
+		if k.E.Cmp(big.NewInt(3)) != 0 {
+			return nil, fmt.Errorf("key %v has e = %v, need e = 3", i, k.E)
+		}
+		moduli[i] = k.N
+	}
+
+	cubed, _, err := rsa.CRT(crypts, moduli)
+	if err != nil {
+		return nil, fmt.Errorf("failed to combine cyphertexts: %v", err)
+	}
+	m, exact := rsa.IntegerNthRoot(cubed, 3)
+	if !exact {
+		return nil, fmt.Errorf("CRT result is not a perfect cube")
+	}
+	return m, nil
+}
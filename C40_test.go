@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+func TestBroadcastAttack(t *testing.T) {
+	ex := []struct {
+		bits int
+		msg  []byte
+	}{
+		{256, []byte("YELLOW SUBMARINE")},
+		{1024, []byte("We all live in a yellow submarine, yellow submarine")},
+	}
+
+	for _, e := range ex {
+		var crypts []*big.Int
+		var keys []*rsa.PublicKey
+		for i := 0; i < 3; i++ {
+			priv, err := rsa.GenerateKey(e.bits, 3)
+			if err != nil {
+				t.Fatalf("failed to generate key: %v", err)
+			}
+			keys = append(keys, &priv.PublicKey)
+			crypts = append(crypts, priv.Encrypt(rsa.OS2IP(e.msg)))
+		}
+
+		result, err := BroadcastAttack(crypts, keys)
+		if err != nil {
+			t.Fatalf("broadcast attack (bits: %v) failed: %v", e.bits, err)
+		}
+		if !bytes.Equal(result.Bytes(), e.msg) {
+			t.Errorf("Broadcast attack (bits: %v) failed: \nExp: %v \nGot: %v", e.bits, e.msg, result.Bytes())
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// DecryptionServer decrypts textbook RSA cyphertexts, but refuses to
+// decrypt the same cyphertext more than once.
+type DecryptionServer struct {
+	priv *rsa.PrivateKey
+	mu   sync.Mutex
+	seen map[[sha256.Size]byte]bool
+}
+
+// NewDecryptionServer creates a decryption server with a freshly
+// generated key of the given size
+func NewDecryptionServer(bits int) (*DecryptionServer, error) {
+	priv, err := rsa.GenerateKey(bits, 65537)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+	return &DecryptionServer{
+		priv: priv,
+		seen: make(map[[sha256.Size]byte]bool),
+	}, nil
+}
+
+// PublicKey returns the public key of the server
+func (s *DecryptionServer) PublicKey() *rsa.PublicKey {
+	return &s.priv.PublicKey
+}
+
+// Decrypt decrypts the given cyphertext, unless its hash has been seen before.
+// Cyphertexts outside [0, N) are rejected, since c + N would otherwise
+// decrypt to the same plaintext under a different hash.
+func (s *DecryptionServer) Decrypt(c *big.Int) (*big.Int, error) {
+	if c.Sign() < 0 || c.Cmp(s.priv.N) >= 0 {
+		return nil, fmt.Errorf("cyphertext out of range")
+	}
+	h := sha256.Sum256(c.Bytes())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen[h] {
+		return nil, fmt.Errorf("cyphertext %x already decrypted", h)
+	}
+	s.seen[h] = true
+	return s.priv.Decrypt(c), nil
+}
+
+// C41 solution
+func C41() {
+	fmt.Println("---------------------- c41 ------------------------")
+	const msg = `{time: 1356304276, social: '555-55-5555'}`
+
+	server, err := NewDecryptionServer(1024)
+	if err != nil {
+		log.Fatalf("failed to start decryption server: %v", err)
+	}
+	// Capture a cyphertext and let the legitimate client decrypt it first
+	crypt := server.PublicKey().Encrypt(rsa.OS2IP([]byte(msg)))
+	if _, err := server.Decrypt(crypt); err != nil {
+		log.Fatalf("initial decryption failed: %v", err)
+	}
+	if _, err := server.Decrypt(crypt); err == nil {
+		log.Fatalf("server decrypted the same cyphertext twice")
+	}
+
+	plain, err := RecoverUnpadded(server, crypt)
+	if err != nil {
+		log.Fatalf("failed to recover plaintext: %v", err)
+	}
+	fmt.Printf("Recovered plaintext: %q\n", plain.Bytes())
+}
+
+// RecoverUnpadded recovers the plaintext of a cyphertext the server
+// refuses to decrypt again, by blinding it.
+//
+// For a random S, C' = S^e * C mod N decrypts to P' = S * P mod N,
+// so P = P' / S mod N.
+func RecoverUnpadded(server *DecryptionServer, c *big.Int) (*big.Int, error) {
+	pub := server.PublicKey()
+
+	var s, sInv *big.Int
+	for {
+		var err error
+		s, err = rand.Int(rand.Reader, pub.N)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate S: %v", err)
+		}
+		// S must be invertible mod N, which a random S practically always is
+		if sInv, err = rsa.InvMod(s, pub.N); err == nil && s.Cmp(big.NewInt(1)) > 0 {
+			break
+		}
+	}
+
+	blinded := pub.Encrypt(s)
+	blinded.Mul(blinded, c)
+	blinded.Mod(blinded, pub.N)
+
+	p, err := server.Decrypt(blinded)
+	if err != nil {
+		return nil, fmt.Errorf("server refused blinded cyphertext: %v", err)
+	}
+	p.Mul(p, sInv)
+	return p.Mod(p, pub.N), nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+func TestRecoverUnpadded(t *testing.T) {
+	ex := []struct {
+		bits int
+		msg  []byte
+	}{
+		{256, []byte("YELLOW SUBMARINE")},
+		{512, []byte("{time: 1356304276, social: '555-55-5555'}")},
+		{1024, []byte("{time: 1356304276, social: '555-55-5555'}")},
+	}
+
+	for _, e := range ex {
+		server, err := NewDecryptionServer(e.bits)
+		if err != nil {
+			t.Fatalf("failed to start server: %v", err)
+		}
+		crypt := server.PublicKey().Encrypt(rsa.OS2IP(e.msg))
+		if _, err := server.Decrypt(crypt); err != nil {
+			t.Fatalf("first decryption failed: %v", err)
+		}
+		if _, err := server.Decrypt(crypt); err == nil {
+			t.Errorf("server (bits: %v) decrypted the same cyphertext twice", e.bits)
+		}
+		shifted := new(big.Int).Add(crypt, server.PublicKey().N)
+		if _, err := server.Decrypt(shifted); err == nil {
+			t.Errorf("server (bits: %v) decrypted the cyphertext again as c + N", e.bits)
+		}
+
+		result, err := RecoverUnpadded(server, crypt)
+		if err != nil {
+			t.Fatalf("recovery (bits: %v) failed: %v", e.bits, err)
+		}
+		if !bytes.Equal(result.Bytes(), e.msg) {
+			t.Errorf("Recovery (bits: %v) failed: \nExp: %v \nGot: %v", e.bits, e.msg, result.Bytes())
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"crypto"
+	"crypto/sha1"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// C42 solution
+func C42() {
+	fmt.Println("---------------------- c42 ------------------------")
+	const msg = "hi mom"
+
+	priv, err := rsa.GenerateKey(1024, 3)
+	if err != nil {
+		log.Fatalf("failed to generate RSA key: %v", err)
+	}
+	hashed := sha1.Sum([]byte(msg))
+	sig, err := ForgePKCS1v15Signature(&priv.PublicKey, crypto.SHA1, hashed[:])
+	if err != nil {
+		log.Fatalf("failed to forge signature: %v", err)
+	}
+	fmt.Printf("Forged signature for %q: %x\n", msg, sig)
+
+	sloppy := rsa.PKCS1v15Verifier{Sloppy: true}
+	fmt.Printf("Accepted by sloppy verifier: %v\n", sloppy.Verify(&priv.PublicKey, crypto.SHA1, hashed[:], sig) == nil)
+	fmt.Printf("Accepted by correct verifier: %v\n", rsa.VerifyPKCS1v15(&priv.PublicKey, crypto.SHA1, hashed[:], sig) == nil)
+}
+
+// ForgePKCS1v15Signature forges a PKCS#1 v1.5 signature for the given digest
+// that is accepted by a sloppy verifier, for any key with e=3.
+//
+// We build the block 00 01 FF 00 ASN.1 HASH GARBAGE, where the garbage fills
+// up the rest of the modulus, and take its cube root rounded up. Cubing the
+// result perturbs only the garbage, which the sloppy verifier ignores.
+func ForgePKCS1v15Signature(pub *rsa.PublicKey, hash crypto.Hash, hashed []byte) ([]byte, error) {
+	if pub.E.Cmp(big.NewInt(3)) != 0 {
+		return nil, fmt.Errorf("forgery requires e = 3, got %v", pub.E)
+	}
+	prefix, ok := rsa.DigestInfoPrefix[hash]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash function %v", hash)
+	}
+
+	k := pub.Size()
+	block := []byte{0x00, 0x01, 0xff, 0x00}
+	block = append(block, prefix...)
+	block = append(block, hashed...)
+	if len(block) > k {
+		return nil, fmt.Errorf("modulus too short for %v signature", hash)
+	}
+	garbageLen := k - len(block)
+
+	// Any cube in [lo, hi] is a forgery: lo has zero garbage, hi all 0xff
+	lo := new(big.Int).Lsh(rsa.OS2IP(block), uint(8*garbageLen))
+	hi := new(big.Int).Lsh(big.NewInt(1), uint(8*garbageLen))
+	hi.Sub(hi, big.NewInt(1))
+	hi.Or(hi, lo)
+
+	s, exact := rsa.IntegerNthRoot(lo, 3)
+	if !exact {
+		s.Add(s, big.NewInt(1))
+	}
+	if new(big.Int).Exp(s, big.NewInt(3), nil).Cmp(hi) > 0 {
+		return nil, fmt.Errorf("not enough garbage space (%v bytes) to forge signature", garbageLen)
+	}
+	return rsa.I2OSP(s, k)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"crypto"
+	"crypto/sha1"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+func TestForgePKCS1v15Signature(t *testing.T) {
+	msg := []byte("hi mom")
+	sha1Sum := sha1.Sum(msg)
+	sha256Sum := sha256.Sum256(msg)
+	ex := []struct {
+		bits   int
+		hash   crypto.Hash
+		hashed []byte
+	}{
+		{1024, crypto.SHA1, sha1Sum[:]},
+		{2048, crypto.SHA1, sha1Sum[:]},
+		{2048, crypto.SHA256, sha256Sum[:]},
+	}
+
+	sloppy := rsa.PKCS1v15Verifier{Sloppy: true}
+	for _, e := range ex {
+		priv, err := rsa.GenerateKey(e.bits, 3)
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		sig, err := ForgePKCS1v15Signature(&priv.PublicKey, e.hash, e.hashed)
+		if err != nil {
+			t.Fatalf("forgery (bits: %v, hash: %v) failed: %v", e.bits, e.hash, err)
+		}
+		if err := sloppy.Verify(&priv.PublicKey, e.hash, e.hashed, sig); err != nil {
+			t.Errorf("Forgery (bits: %v, hash: %v) rejected by sloppy verifier: %v", e.bits, e.hash, err)
+		}
+		if err := rsa.VerifyPKCS1v15(&priv.PublicKey, e.hash, e.hashed, sig); err == nil {
+			t.Errorf("Forgery (bits: %v, hash: %v) accepted by correct verifier", e.bits, e.hash)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dsa"
+)
+
+const (
+	c43Msg = "For those that envy a MC it can be hazardous to your health\n" +
+		"So be friendly, a matter of life and death, just like a etch-a-sketch\n"
+	c43Y = "84ad4719d044495496a3201c8ff484feb45b962e7302e56a392aee4abab3e4bdebf295" +
+		"5b4736012f21a08084056b19bcd7fee56048e004e44984e2f411788efdc837a0d2e5abb7" +
+		"b555039fd243ac01f0fb2ed1dec568280ce678e931868d23eb095fde9d3779191b8c0299" +
+		"d6e07bbb283e6633451e535c45513b2d33c99ea17"
+	c43R = "548099063082341131477253921760299949438196259240"
+	c43S = "857042759984254168557880549501802188789837994940"
+)
+
+// C43 solution
+func C43() {
+	fmt.Println("---------------------- c43 ------------------------")
+	y, _ := new(big.Int).SetString(c43Y, 16)
+	r, _ := new(big.Int).SetString(c43R, 10)
+	s, _ := new(big.Int).SetString(c43S, 10)
+	pub := &dsa.PublicKey{Parameters: dsa.DefaultParameters, Y: y}
+	hashed := sha1.Sum([]byte(c43Msg))
+
+	x, k, err := BruteForceNonce(pub, hashed[:], r, s, 1<<16)
+	if err != nil {
+		log.Fatalf("failed to recover key: %v", err)
+	}
+	fmt.Printf("Recovered nonce k = %v\n", k)
+	fmt.Printf("Recovered private key x = %x (SHA1 of hex: %x)\n", x, sha1.Sum([]byte(x.Text(16))))
+}
+
+// XFromNonce recovers the private key x from a signature (r, s) of the given
+// digest, given the nonce k that was used to create it:
+// x = (s*k - H(m)) / r mod q
+func XFromNonce(params dsa.Parameters, hashed []byte, r, s, k *big.Int) (*big.Int, error) {
+	rInv := new(big.Int).ModInverse(r, params.Q)
+	if rInv == nil {
+		return nil, fmt.Errorf("r = %v is not invertible mod q", r)
+	}
+	x := new(big.Int).Mul(s, k)
+	x.Sub(x, dsa.HashToInt(hashed, params.Q))
+	x.Mul(x, rInv)
+	return x.Mod(x, params.Q), nil
+}
+
+// BruteForceNonce recovers the private key for a signature whose nonce k was
+// drawn from [0, max). Returns the key x and the nonce k.
+func BruteForceNonce(pub *dsa.PublicKey, hashed []byte, r, s *big.Int, max int64) (x, k *big.Int, err error) {
+	// r = (g^k mod p) mod q only depends on k, so step through
+	// g^k incrementally and compare against r
+	gk := big.NewInt(1)
+	rk := new(big.Int)
+	for i := int64(0); i < max; i++ {
+		if rk.Mod(gk, pub.Q).Cmp(r) == 0 {
+			k = big.NewInt(i)
+			x, err = XFromNonce(pub.Parameters, hashed, r, s, k)
+			if err != nil {
+				return nil, nil, err
+			}
+			if new(big.Int).Exp(pub.G, x, pub.P).Cmp(pub.Y) == 0 {
+				return x, k, nil
+			}
+		}
+		gk.Mul(gk, pub.G)
+		gk.Mod(gk, pub.P)
+	}
+	return nil, nil, fmt.Errorf("no nonce in [0, %v) matches the signature", max)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dsa"
+)
+
+func TestBruteForceNonce(t *testing.T) {
+	ex := []struct {
+		msg string
+		k   int64
+	}{
+		{"hi mom", 1},
+		{"For those that envy a MC it can be hazardous to your health", 4242},
+		{"So be friendly, a matter of life and death", 65535},
+	}
+
+	priv, err := dsa.GenerateKey(dsa.DefaultParameters)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	for _, e := range ex {
+		hashed := sha1.Sum([]byte(e.msg))
+		r, s, err := dsa.SignWithNonce(priv, hashed[:], big.NewInt(e.k))
+		if err != nil {
+			t.Fatalf("failed to sign %q: %v", e.msg, err)
+		}
+		x, k, err := BruteForceNonce(&priv.PublicKey, hashed[:], r, s, 1<<16)
+		if err != nil {
+			t.Fatalf("brute force for %q failed: %v", e.msg, err)
+		}
+		if k.Int64() != e.k || x.Cmp(priv.X) != 0 {
+			t.Errorf("Brute force for %q failed: \nExp: k=%v x=%v \nGot: k=%v x=%v", e.msg, e.k, priv.X, k, x)
+		}
+	}
+}
+
+func TestC43Key(t *testing.T) {
+	const expected = "0954edd5e0afe5542a4adf012611a91912a3ec16"
+
+	y, _ := new(big.Int).SetString(c43Y, 16)
+	r, _ := new(big.Int).SetString(c43R, 10)
+	s, _ := new(big.Int).SetString(c43S, 10)
+	pub := &dsa.PublicKey{Parameters: dsa.DefaultParameters, Y: y}
+	hashed := sha1.Sum([]byte(c43Msg))
+
+	x, _, err := BruteForceNonce(pub, hashed[:], r, s, 1<<16)
+	if err != nil {
+		t.Fatalf("failed to recover key: %v", err)
+	}
+	if got := fmt.Sprintf("%x", sha1.Sum([]byte(x.Text(16)))); got != expected {
+		t.Errorf("Recovered key has wrong fingerprint: \nExp: %v \nGot: %v", expected, got)
+	}
+}
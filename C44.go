@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dsa"
+)
+
+// SignedMessage is a DSA signed message as found in the C44 data file
+type SignedMessage struct {
+	Msg  string
+	S, R *big.Int
+	M    *big.Int // SHA1 digest of Msg
+}
+
+// c44Y is the public key that signed the messages in the C44 data file
+const c44Y = "2d026f4bf30195ede3a088da85e398ef869611d0f68f0713d51c9c1a3a26c95105d915e2d8cdf26d056b86b8a7b85519b1c23cc3ecdc6062650462e3063bd179c2a6581519f674a61f1d89a1fff27171ebc1b93d4dc57bceb7ae2430f98a6a4d83d8279ee65d71c1203d2c96d65ebbf7cce9d32971c3de5084cce04a2e147821"
+
+// C44 solution
+func C44() {
+	fmt.Println("---------------------- c44 ------------------------")
+	x, err := C44RecoverKey("c44data.txt")
+	if err != nil {
+		log.Fatalf("failed to recover key: %v", err)
+	}
+	fmt.Printf("Recovered private key x = %x, SHA1 fingerprint %x\n", x, sha1.Sum([]byte(x.Text(16))))
+}
+
+// C44RecoverKey recovers the private key behind the signed messages in the
+// given data file, which were signed with the C44 public key
+func C44RecoverKey(path string) (*big.Int, error) {
+	y, ok := new(big.Int).SetString(c44Y, 16)
+	if !ok {
+		return nil, fmt.Errorf("malformed public key %q", c44Y)
+	}
+	pub := &dsa.PublicKey{Parameters: dsa.DefaultParameters, Y: y}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open data file: %v", err)
+	}
+	defer f.Close()
+	msgs, err := ParseSignedMessages(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signed messages: %v", err)
+	}
+	return RecoverRepeatedNonce(pub, msgs)
+}
+
+// ParseSignedMessages parses signed messages in the format of the C44
+// data file, i.e. groups of "msg: ", "s: ", "r: " and "m: " lines where
+// s and r are decimal and m is hex encoded.
+func ParseSignedMessages(rd io.Reader) ([]SignedMessage, error) {
+	var out []SignedMessage
+	var cur SignedMessage
+	fields := 0
+
+	scanner := bufio.NewScanner(rd)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		kv := strings.SplitN(line, ": ", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+		var ok bool
+		switch kv[0] {
+		case "msg":
+			cur.Msg, ok = kv[1], true
+		case "s":
+			cur.S, ok = new(big.Int).SetString(strings.TrimSpace(kv[1]), 10)
+		case "r":
+			cur.R, ok = new(big.Int).SetString(strings.TrimSpace(kv[1]), 10)
+		case "m":
+			cur.M, ok = new(big.Int).SetString(strings.TrimSpace(kv[1]), 16)
+		default:
+			return nil, fmt.Errorf("unknown field in line %q", line)
+		}
+		if !ok {
+			return nil, fmt.Errorf("malformed value in line %q", line)
+		}
+
+		fields++
+		if fields == 4 {
+			if cur.S == nil || cur.R == nil || cur.M == nil {
+				return nil, fmt.Errorf("incomplete signed message %+v", cur)
+			}
+			out = append(out, cur)
+			cur, fields = SignedMessage{}, 0
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if fields != 0 {
+		return nil, fmt.Errorf("trailing incomplete signed message %+v", cur)
+	}
+	return out, nil
+}
+
+// RecoverRepeatedNonce finds two messages that were signed with the same
+// nonce and uses them to recover the private key.
+//
+// A repeated nonce shows up as a repeated r. For two such signatures
+// k = (m1 - m2) / (s1 - s2) mod q, from which x follows.
+func RecoverRepeatedNonce(pub *dsa.PublicKey, msgs []SignedMessage) (*big.Int, error) {
+	q := pub.Q
+	seen := make(map[string]SignedMessage)
+	for _, m2 := range msgs {
+		m1, ok := seen[m2.R.String()]
+		if !ok {
+			seen[m2.R.String()] = m2
+			continue
+		}
+
+		ds := new(big.Int).Sub(m1.S, m2.S)
+		ds.Mod(ds, q)
+		dsInv := ds.ModInverse(ds, q)
+		if dsInv == nil {
+			continue
+		}
+		k := new(big.Int).Sub(m1.M, m2.M)
+		k.Mul(k, dsInv)
+		k.Mod(k, q)
+
+		x, err := XFromNonce(pub.Parameters, m1.M.Bytes(), m1.R, m1.S, k)
+		if err != nil {
+			return nil, err
+		}
+		if new(big.Int).Exp(pub.G, x, pub.P).Cmp(pub.Y) == 0 {
+			return x, nil
+		}
+	}
+	return nil, fmt.Errorf("no repeated nonce found among %v messages", len(msgs))
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dsa"
+)
+
+func TestParseSignedMessages(t *testing.T) {
+	const input = `msg: Listen for me, you better listen for me now. 
+s: 1267396447369736888040262262183731677867615804316
+r: 1105520928110492191417703162650245113664610474875
+m: a4db3de27e2db3e5ef085ced2bced91b82e0df19
+msg: Listen for me, you better listen for me now. 
+s: 29097472083055673620219739525237952924429516683
+r: 51241962016175933742870323080382366896234169532
+m: a4db3de27e2db3e5ef085ced2bced91b82e0df19
+`
+	msgs, err := ParseSignedMessages(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("Parse failed: Expected: 2 messages Got: %v", len(msgs))
+	}
+	m := msgs[1]
+	if m.Msg != "Listen for me, you better listen for me now. " ||
+		m.S.String() != "29097472083055673620219739525237952924429516683" ||
+		m.R.String() != "51241962016175933742870323080382366896234169532" ||
+		m.M.Text(16) != "a4db3de27e2db3e5ef085ced2bced91b82e0df19" {
+		t.Errorf("Parse failed, got %+v", m)
+	}
+
+	if _, err := ParseSignedMessages(strings.NewReader("msg: foo\ns: 1\n")); err == nil {
+		t.Errorf("Parsing incomplete message should fail")
+	}
+	if _, err := ParseSignedMessages(strings.NewReader("msg: foo\ns: x\nr: 1\nm: 1\n")); err == nil {
+		t.Errorf("Parsing malformed value should fail")
+	}
+}
+
+func TestRecoverRepeatedNonce(t *testing.T) {
+	msgs := []string{"a", "b", "c", "d", "e"}
+
+	priv, err := dsa.GenerateKey(dsa.DefaultParameters)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var sb strings.Builder
+	for i, m := range msgs {
+		hashed := sha1.Sum([]byte(m))
+		// only the second and fifth messages share a nonce
+		k := big.NewInt(int64(1000 + i))
+		if i == 4 {
+			k = big.NewInt(1001)
+		}
+		r, s, err := dsa.SignWithNonce(priv, hashed[:], k)
+		if err != nil {
+			t.Fatalf("failed to sign %q: %v", m, err)
+		}
+		fmt.Fprintf(&sb, "msg: %v\ns: %v\nr: %v\nm: %x\n", m, s, r, hashed)
+	}
+
+	signed, err := ParseSignedMessages(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	x, err := RecoverRepeatedNonce(&priv.PublicKey, signed)
+	if err != nil {
+		t.Fatalf("recovery failed: %v", err)
+	}
+	if x.Cmp(priv.X) != 0 {
+		t.Errorf("Recovery failed: \nExp: %v \nGot: %v", priv.X, x)
+	}
+
+	if _, err := RecoverRepeatedNonce(&priv.PublicKey, signed[:4]); err == nil {
+		t.Errorf("Recovery without repeated nonce should fail")
+	}
+}
+
+func TestC44RecoverKey(t *testing.T) {
+	const expected = "ca8f6f7c66fa362d40760d135b763eb8527d3d52"
+	x, err := C44RecoverKey("c44data.txt")
+	if err != nil {
+		t.Fatalf("recovery failed: %v", err)
+	}
+	if fp := fmt.Sprintf("%x", sha1.Sum([]byte(x.Text(16)))); fp != expected {
+		t.Errorf("Key fingerprint failed: \nExp: %v \nGot: %v", expected, fp)
+	}
+}
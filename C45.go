@@ -0,0 +1,80 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dsa"
+)
+
+// C45 solution
+func C45() {
+	fmt.Println("---------------------- c45 ------------------------")
+	msgs := []string{"Hello, world", "Goodbye, world"}
+
+	// g = 0: the public key is 0 and r = 0 verifies for anything
+	priv, err := dsa.GenerateKey(dsa.DefaultParameters.WithGenerator(big.NewInt(0)))
+	if err != nil {
+		log.Fatalf("failed to generate key: %v", err)
+	}
+	r, s, err := ZeroGeneratorSignature(&priv.PublicKey)
+	if err != nil {
+		log.Fatalf("failed to generate signature: %v", err)
+	}
+	for _, m := range msgs {
+		hashed := sha1.Sum([]byte(m))
+		fmt.Printf("g = 0: (%v, %v) valid for %q? unsafe: %v, hardened: %v\n",
+			r, s, m, dsa.VerifyUnsafe(&priv.PublicKey, hashed[:], r, s), dsa.Verify(&priv.PublicKey, hashed[:], r, s))
+	}
+
+	// g = p + 1: magic signatures
+	g := new(big.Int).Add(dsa.DefaultParameters.P, big.NewInt(1))
+	priv, err = dsa.GenerateKey(dsa.DefaultParameters.WithGenerator(g))
+	if err != nil {
+		log.Fatalf("failed to generate key: %v", err)
+	}
+	r, s, err = MagicSignature(&priv.PublicKey)
+	if err != nil {
+		log.Fatalf("failed to generate magic signature: %v", err)
+	}
+	for _, m := range msgs {
+		hashed := sha1.Sum([]byte(m))
+		fmt.Printf("g = p+1: (%v, %v) valid for %q? unsafe: %v, hardened: %v\n",
+			r, s, m, dsa.VerifyUnsafe(&priv.PublicKey, hashed[:], r, s), dsa.Verify(&priv.PublicKey, hashed[:], r, s))
+	}
+}
+
+// ZeroGeneratorSignature creates a signature that an unsafe verifier accepts
+// for any message when g = 0.
+//
+// With g = 0 every g^u1 and the public key y are 0, so v = 0 and r = 0
+// matches regardless of s.
+func ZeroGeneratorSignature(pub *dsa.PublicKey) (r, s *big.Int, err error) {
+	s, err = rand.Int(rand.Reader, new(big.Int).Sub(pub.Q, big.NewInt(1)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return big.NewInt(0), s.Add(s, big.NewInt(1)), nil
+}
+
+// MagicSignature creates a signature that an unsafe verifier accepts for
+// any message when g = p + 1.
+//
+// With g = 1 mod p, g^u1 = 1 and v = y^u2 = y^(r/s). Picking an arbitrary z
+// and setting r = (y^z mod p) mod q, s = r/z mod q makes r/s = z, so v = r.
+func MagicSignature(pub *dsa.PublicKey) (r, s *big.Int, err error) {
+	z, err := rand.Int(rand.Reader, new(big.Int).Sub(pub.Q, big.NewInt(1)))
+	if err != nil {
+		return nil, nil, err
+	}
+	z.Add(z, big.NewInt(1))
+
+	r = new(big.Int).Exp(pub.Y, z, pub.P)
+	r.Mod(r, pub.Q)
+	zInv := new(big.Int).ModInverse(z, pub.Q)
+	s = new(big.Int).Mul(r, zInv)
+	return r, s.Mod(s, pub.Q), nil
+}
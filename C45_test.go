@@ -0,0 +1,41 @@
+package main
+
+import (
+	"crypto/sha1"
+	"math/big"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dsa"
+)
+
+func TestGeneratorTampering(t *testing.T) {
+	msgs := []string{"Hello, world", "Goodbye, world", ""}
+	ex := []struct {
+		name string
+		g    *big.Int
+		sign func(*dsa.PublicKey) (*big.Int, *big.Int, error)
+	}{
+		{"g = 0", big.NewInt(0), ZeroGeneratorSignature},
+		{"g = p+1", new(big.Int).Add(dsa.DefaultParameters.P, big.NewInt(1)), MagicSignature},
+	}
+
+	for _, e := range ex {
+		priv, err := dsa.GenerateKey(dsa.DefaultParameters.WithGenerator(e.g))
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		r, s, err := e.sign(&priv.PublicKey)
+		if err != nil {
+			t.Fatalf("failed to generate signature with %v: %v", e.name, err)
+		}
+		for _, m := range msgs {
+			hashed := sha1.Sum([]byte(m))
+			if !dsa.VerifyUnsafe(&priv.PublicKey, hashed[:], r, s) {
+				t.Errorf("Unsafe verifier rejected %v signature for %q", e.name, m)
+			}
+			if dsa.Verify(&priv.PublicKey, hashed[:], r, s) {
+				t.Errorf("Hardened verifier accepted %v signature for %q", e.name, m)
+			}
+		}
+	}
+}
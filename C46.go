@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// ParityOracle decrypts RSA cyphertexts and only reveals whether the
+// resulting plaintext is even or odd
+type ParityOracle struct {
+	priv *rsa.PrivateKey
+}
+
+// NewParityOracle creates a parity oracle with a freshly generated key
+// of the given size
+func NewParityOracle(bits int) (*ParityOracle, error) {
+	priv, err := rsa.GenerateKey(bits, 65537)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+	return &ParityOracle{priv: priv}, nil
+}
+
+// PublicKey returns the public key of the oracle
+func (o *ParityOracle) PublicKey() *rsa.PublicKey {
+	return &o.priv.PublicKey
+}
+
+// IsEven reports whether the given cyphertext decrypts to an even plaintext
+func (o *ParityOracle) IsEven(c *big.Int) bool {
+	return o.priv.Decrypt(c).Bit(0) == 0
+}
+
+// C46 solution
+func C46() {
+	fmt.Println("---------------------- c46 ------------------------")
+	const secret = "VGhhdCdzIHdoeSBJIGZvdW5kIHlvdSBkb24ndCBwbGF5IGFyb3VuZCB3aXRoIHRoZSBGdW5reSBDb2xkIE1lZGluYQ=="
+
+	msg, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		log.Fatalf("failed to decode secret: %v", err)
+	}
+	oracle, err := NewParityOracle(1024)
+	if err != nil {
+		log.Fatalf("failed to create oracle: %v", err)
+	}
+	crypt := oracle.PublicKey().Encrypt(rsa.OS2IP(msg))
+
+	i := 0
+	plain := ParityAttack(oracle, crypt, func(hi *big.Int) {
+		// hollywood style, but not too noisy
+		if i++; i%64 == 0 {
+			fmt.Printf("%q\n", hi.Bytes())
+		}
+	})
+	fmt.Printf("Recovered plaintext: %q\n", plain.Bytes())
+}
+
+// ParityAttack recovers the plaintext of c using the parity oracle.
+//
+// Multiplying c by 2^e doubles the plaintext mod N. Since N is odd, 2P mod N
+// is even iff 2P < N, i.e. iff P < N/2. Repeating this halves the interval
+// containing P each time, so after log2(N) queries [lo, hi) is narrower than
+// 1 and P is the only integer in it, ceil(lo). The bounds are kept as exact
+// rationals so that rounding doesn't corrupt the final bits.
+// If progress is non-nil, it is called with the upper bound after each step.
+func ParityAttack(oracle *ParityOracle, c *big.Int, progress func(hi *big.Int)) *big.Int {
+	pub := oracle.PublicKey()
+	double := pub.Encrypt(big.NewInt(2))
+
+	lo := new(big.Rat)
+	hi := new(big.Rat).SetInt(pub.N)
+	cur := new(big.Int).Set(c)
+	for i := 0; i < pub.N.BitLen(); i++ {
+		cur.Mul(cur, double)
+		cur.Mod(cur, pub.N)
+
+		mid := new(big.Rat).Add(lo, hi)
+		mid.Quo(mid, big.NewRat(2, 1))
+		if oracle.IsEven(cur) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+		if progress != nil {
+			progress(ratFloor(hi))
+		}
+	}
+	return ratCeil(lo)
+}
+
+// ratFloor returns the largest integer <= r for non-negative r
+func ratFloor(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+// ratCeil returns the smallest integer >= r for non-negative r
+func ratCeil(r *big.Rat) *big.Int {
+	n := new(big.Int).Add(r.Num(), r.Denom())
+	n.Sub(n, big.NewInt(1))
+	return n.Quo(n, r.Denom())
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+func TestParityAttack(t *testing.T) {
+	ex := []struct {
+		bits int
+		msg  []byte
+	}{
+		{256, []byte("YELLOW SUBMARINE")},
+		{512, []byte("That's why I found you don't play around")},
+		{1024, []byte("That's why I found you don't play around with the Funky Cold Medina")},
+		{1024, []byte{0x01}},
+	}
+
+	for _, e := range ex {
+		oracle, err := NewParityOracle(e.bits)
+		if err != nil {
+			t.Fatalf("failed to create oracle: %v", err)
+		}
+		crypt := oracle.PublicKey().Encrypt(rsa.OS2IP(e.msg))
+
+		var last *big.Int
+		steps := 0
+		result := ParityAttack(oracle, crypt, func(hi *big.Int) {
+			if last != nil && hi.Cmp(last) > 0 {
+				t.Errorf("Upper bound increased from %v to %v", last, hi)
+			}
+			last = hi
+			steps++
+		})
+		if !bytes.Equal(result.Bytes(), e.msg) {
+			t.Errorf("Parity attack (bits: %v) failed: \nExp: %v \nGot: %v", e.bits, e.msg, result.Bytes())
+		}
+		if steps != oracle.PublicKey().N.BitLen() {
+			t.Errorf("Parity attack (bits: %v) took %v steps, expected log2(N)", e.bits, steps)
+		}
+	}
+}
+
+func TestParityAttackBounds(t *testing.T) {
+	oracle, err := NewParityOracle(256)
+	if err != nil {
+		t.Fatalf("failed to create oracle: %v", err)
+	}
+	n := oracle.PublicKey().N
+	for _, p := range []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Sub(n, big.NewInt(1))} {
+		crypt := oracle.PublicKey().Encrypt(p)
+		if result := ParityAttack(oracle, crypt, nil); result.Cmp(p) != 0 {
+			t.Errorf("Parity attack failed: \nExp: %v \nGot: %v", p, result)
+		}
+	}
+}
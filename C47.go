@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// PaddingOracle decrypts RSA cyphertexts and only reveals whether the
+// plaintext is PKCS#1 v1.5 conforming, i.e. starts with 00 02
+type PaddingOracle struct {
+	priv *rsa.PrivateKey
+}
+
+// NewPaddingOracle creates a padding oracle with a freshly generated key
+// of the given size
+func NewPaddingOracle(bits int) (*PaddingOracle, error) {
+	priv, err := rsa.GenerateKey(bits, 3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+	return &PaddingOracle{priv: priv}, nil
+}
+
+// PublicKey returns the public key of the oracle
+func (o *PaddingOracle) PublicKey() *rsa.PublicKey {
+	return &o.priv.PublicKey
+}
+
+// Conforming reports whether the given cyphertext decrypts to a
+// plaintext starting with 00 02
+func (o *PaddingOracle) Conforming(c *big.Int) bool {
+	em, err := rsa.I2OSP(o.priv.Decrypt(c), o.priv.Size())
+	if err != nil {
+		return false
+	}
+	return em[0] == 0x00 && em[1] == 0x02
+}
+
+// Interval is a closed interval [Lo, Hi] of integers
+type Interval struct {
+	Lo, Hi *big.Int
+}
+
+// Bleichenbacher holds the state of Bleichenbacher's 1998 padding oracle
+// attack against PKCS#1 v1.5 encryption. Queries and Intervals are exposed
+// so the progress of the attack can be analysed.
+type Bleichenbacher struct {
+	oracle *PaddingOracle
+	pub    *rsa.PublicKey
+	b2, b3 *big.Int // 2B and 3B
+
+	// Queries is the number of oracle queries made so far
+	Queries int
+	// Iterations is the number of completed narrowing steps
+	Iterations int
+	// Intervals is the current set of intervals M_i containing the plaintext
+	Intervals []Interval
+}
+
+// NewBleichenbacher prepares an attack against the given padding oracle
+func NewBleichenbacher(oracle *PaddingOracle) *Bleichenbacher {
+	pub := oracle.PublicKey()
+	b := new(big.Int).Lsh(big.NewInt(1), uint(8*(pub.Size()-2)))
+	return &Bleichenbacher{
+		oracle: oracle,
+		pub:    pub,
+		b2:     new(big.Int).Mul(big.NewInt(2), b),
+		b3:     new(big.Int).Mul(big.NewInt(3), b),
+	}
+}
+
+// C47 solution
+func C47() {
+	fmt.Println("---------------------- c47 ------------------------")
+	bleichenbacherDemo(256, "kick it, CC")
+}
+
+// C48 solution
+func C48() {
+	fmt.Println("---------------------- c48 ------------------------")
+	bleichenbacherDemo(768, "kick it, CC")
+}
+
+func bleichenbacherDemo(bits int, msg string) {
+	oracle, err := NewPaddingOracle(bits)
+	if err != nil {
+		log.Fatalf("failed to create oracle: %v", err)
+	}
+	crypt, err := rsa.EncryptPKCS1v15(oracle.PublicKey(), []byte(msg))
+	if err != nil {
+		log.Fatalf("failed to encrypt %q: %v", msg, err)
+	}
+
+	attack := NewBleichenbacher(oracle)
+	m, err := attack.Run(rsa.OS2IP(crypt))
+	if err != nil {
+		log.Fatalf("attack failed: %v", err)
+	}
+	em, err := rsa.I2OSP(m, oracle.PublicKey().Size())
+	if err != nil {
+		log.Fatalf("recovered plaintext out of range: %v", err)
+	}
+	plain, err := rsa.UnpadPKCS1v15(em)
+	if err != nil {
+		log.Fatalf("recovered plaintext not conforming: %v", err)
+	}
+	fmt.Printf("Recovered plaintext %q after %v queries, %v iterations\n", plain, attack.Queries, attack.Iterations)
+}
+
+// Run recovers the padded plaintext of the PKCS#1 conforming cyphertext c.
+// See "Chosen Ciphertext Attacks Against Protocols Based on the RSA
+// Encryption Standard PKCS #1" for the step numbering.
+func (a *Bleichenbacher) Run(c *big.Int) (*big.Int, error) {
+	n := a.pub.N
+
+	// Step 1: c is already conforming, so no blinding is needed (s0 = 1)
+	if !a.query(c, big.NewInt(1)) {
+		return nil, fmt.Errorf("cyphertext is not PKCS#1 conforming")
+	}
+	a.Intervals = []Interval{{Lo: new(big.Int).Set(a.b2), Hi: new(big.Int).Sub(a.b3, big.NewInt(1))}}
+	a.Iterations = 0
+
+	var s *big.Int
+	for {
+		switch {
+		case a.Iterations == 0:
+			// Step 2a: smallest s >= n/3B that gives a conforming cyphertext
+			s = a.searchFrom(c, ceilDiv(n, a.b3))
+		case len(a.Intervals) > 1:
+			// Step 2b: more than one interval left, keep searching upward
+			s = a.searchFrom(c, new(big.Int).Add(s, big.NewInt(1)))
+		default:
+			// Step 2c: one interval left, search along r
+			s = a.searchSingle(c, s)
+		}
+
+		// Step 3: narrow the set of solutions
+		a.Intervals = a.narrow(s)
+		a.Iterations++
+		if len(a.Intervals) == 0 {
+			return nil, fmt.Errorf("no intervals left after %v iterations", a.Iterations)
+		}
+
+		// Step 4: done once the interval is a single number
+		if len(a.Intervals) == 1 && a.Intervals[0].Lo.Cmp(a.Intervals[0].Hi) == 0 {
+			return new(big.Int).Set(a.Intervals[0].Lo), nil
+		}
+	}
+}
+
+// query asks the oracle whether c * s^e is conforming
+func (a *Bleichenbacher) query(c, s *big.Int) bool {
+	a.Queries++
+	cs := a.pub.Encrypt(s)
+	cs.Mul(cs, c)
+	cs.Mod(cs, a.pub.N)
+	return a.oracle.Conforming(cs)
+}
+
+// searchFrom returns the smallest s >= start for which c * s^e is conforming
+func (a *Bleichenbacher) searchFrom(c, start *big.Int) *big.Int {
+	s := new(big.Int).Set(start)
+	for !a.query(c, s) {
+		s.Add(s, big.NewInt(1))
+	}
+	return s
+}
+
+// searchSingle implements step 2c for a single interval [a, b]: pick
+// r >= 2(b*s - 2B)/n and try s in [(2B + rn)/b, (3B + rn)/a) for each r
+func (a *Bleichenbacher) searchSingle(c, prev *big.Int) *big.Int {
+	n := a.pub.N
+	lo, hi := a.Intervals[0].Lo, a.Intervals[0].Hi
+
+	r := new(big.Int).Mul(hi, prev)
+	r.Sub(r, a.b2)
+	r.Mul(r, big.NewInt(2))
+	r = ceilDiv(r, n)
+	for ; ; r.Add(r, big.NewInt(1)) {
+		rn := new(big.Int).Mul(r, n)
+		sLo := ceilDiv(new(big.Int).Add(a.b2, rn), hi)
+		sHi := ceilDiv(new(big.Int).Add(a.b3, rn), lo) // exclusive
+		for s := sLo; s.Cmp(sHi) < 0; s.Add(s, big.NewInt(1)) {
+			if a.query(c, s) {
+				return s
+			}
+		}
+	}
+}
+
+// narrow implements step 3: for every interval [a, b] and every
+// r in [(a*s - 3B + 1)/n, (b*s - 2B)/n], intersect [a, b] with
+// [(2B + rn)/s, (3B - 1 + rn)/s] and merge the results
+func (a *Bleichenbacher) narrow(s *big.Int) []Interval {
+	n := a.pub.N
+	b3m1 := new(big.Int).Sub(a.b3, big.NewInt(1))
+
+	var out []Interval
+	for _, in := range a.Intervals {
+		rLo := new(big.Int).Mul(in.Lo, s)
+		rLo.Sub(rLo, b3m1)
+		rLo = ceilDiv(rLo, n)
+		rHi := new(big.Int).Mul(in.Hi, s)
+		rHi.Sub(rHi, a.b2)
+		rHi.Div(rHi, n)
+
+		for r := rLo; r.Cmp(rHi) <= 0; r = new(big.Int).Add(r, big.NewInt(1)) {
+			rn := new(big.Int).Mul(r, n)
+			lo := ceilDiv(new(big.Int).Add(a.b2, rn), s)
+			if lo.Cmp(in.Lo) < 0 {
+				lo = in.Lo
+			}
+			hi := new(big.Int).Div(new(big.Int).Add(b3m1, rn), s)
+			if hi.Cmp(in.Hi) > 0 {
+				hi = in.Hi
+			}
+			if lo.Cmp(hi) <= 0 {
+				out = mergeInterval(out, Interval{Lo: lo, Hi: hi})
+			}
+		}
+	}
+	return out
+}
+
+// mergeInterval adds in to the set of disjoint intervals, merging it
+// with any intervals it overlaps
+func mergeInterval(set []Interval, in Interval) []Interval {
+	merged := Interval{Lo: new(big.Int).Set(in.Lo), Hi: new(big.Int).Set(in.Hi)}
+	var out []Interval
+	for _, cur := range set {
+		if cur.Hi.Cmp(merged.Lo) < 0 || cur.Lo.Cmp(merged.Hi) > 0 {
+			out = append(out, cur)
+			continue
+		}
+		if cur.Lo.Cmp(merged.Lo) < 0 {
+			merged.Lo.Set(cur.Lo)
+		}
+		if cur.Hi.Cmp(merged.Hi) > 0 {
+			merged.Hi.Set(cur.Hi)
+		}
+	}
+	return append(out, merged)
+}
+
+// ceilDiv returns ceil(x / y) for positive y
+func ceilDiv(x, y *big.Int) *big.Int {
+	q, m := new(big.Int).DivMod(x, y, new(big.Int))
+	if m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
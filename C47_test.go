@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+func TestBleichenbacher(t *testing.T) {
+	ex := []struct {
+		bits int
+		msg  []byte
+	}{
+		{256, []byte("kick it, CC")},
+		{256, []byte("YELLOW SUBMARINE")},
+		{768, []byte("kick it, CC")},
+	}
+
+	for _, e := range ex {
+		if e.bits > 256 && testing.Short() {
+			continue
+		}
+		oracle, err := NewPaddingOracle(e.bits)
+		if err != nil {
+			t.Fatalf("failed to create oracle: %v", err)
+		}
+		crypt, err := rsa.EncryptPKCS1v15(oracle.PublicKey(), e.msg)
+		if err != nil {
+			t.Fatalf("failed to encrypt: %v", err)
+		}
+
+		attack := NewBleichenbacher(oracle)
+		m, err := attack.Run(rsa.OS2IP(crypt))
+		if err != nil {
+			t.Fatalf("attack (bits: %v) failed: %v", e.bits, err)
+		}
+		em, err := rsa.I2OSP(m, oracle.PublicKey().Size())
+		if err != nil {
+			t.Fatalf("recovered plaintext out of range: %v", err)
+		}
+		result, err := rsa.UnpadPKCS1v15(em)
+		if err != nil {
+			t.Fatalf("recovered plaintext %x not conforming: %v", em, err)
+		}
+		if !bytes.Equal(result, e.msg) {
+			t.Errorf("Bleichenbacher (bits: %v) failed: \nExp: %v \nGot: %v", e.bits, e.msg, result)
+		}
+		if attack.Queries == 0 || len(attack.Intervals) != 1 {
+			t.Errorf("Bleichenbacher (bits: %v) reported %v queries and %v intervals", e.bits, attack.Queries, len(attack.Intervals))
+		}
+	}
+}
+
+func TestMergeInterval(t *testing.T) {
+	var set []Interval
+	set = mergeInterval(set, Interval{Lo: bigInt(10), Hi: bigInt(20)})
+	set = mergeInterval(set, Interval{Lo: bigInt(30), Hi: bigInt(40)})
+	if len(set) != 2 {
+		t.Fatalf("Disjoint intervals merged: %v", set)
+	}
+	set = mergeInterval(set, Interval{Lo: bigInt(15), Hi: bigInt(35)})
+	if len(set) != 1 || set[0].Lo.Int64() != 10 || set[0].Hi.Int64() != 40 {
+		t.Errorf("Overlapping intervals not merged: %v", set)
+	}
+}
+
+func bigInt(x int64) *big.Int {
+	return big.NewInt(x)
+}
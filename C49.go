@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+// Transfer is a single money transfer between two accounts
+type Transfer struct {
+	From   string
+	To     string
+	Amount int
+}
+
+// APIServer simulates a bank API that accepts CBC-MAC authenticated
+// transfer requests. The key is shared between the server and the web
+// client; the client only ever signs requests on behalf of the account
+// its user is logged in as.
+type APIServer struct {
+	key []byte
+}
+
+// NewAPIServer creates an API server with a random key
+func NewAPIServer() (*APIServer, error) {
+	key, err := pals.GenerateRandomBytes(keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+	return &APIServer{key: key}, nil
+}
+
+// SignTransfer is the client side of the first protocol: it creates
+// the request message || IV || MAC for a transfer from the given account,
+// using a random IV.
+func (s *APIServer) SignTransfer(from, to string, amount int) ([]byte, error) {
+	msg := fmt.Sprintf("from=%v&to=%v&amount=%v", sanitize(from), sanitize(to), amount)
+	iv, err := pals.GenerateRandomBytes(keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate IV: %v", err)
+	}
+	mac, err := pals.CBCMAC(s.key, iv, []byte(msg))
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte(msg), iv...), mac...), nil
+}
+
+// VerifyTransfer verifies and parses a request message || IV || MAC
+func (s *APIServer) VerifyTransfer(req []byte) (Transfer, error) {
+	if len(req) < 2*keySize {
+		return Transfer{}, fmt.Errorf("request too short")
+	}
+	msg := req[:len(req)-2*keySize]
+	iv := req[len(req)-2*keySize : len(req)-keySize]
+	if err := s.checkMAC(iv, msg, req[len(req)-keySize:]); err != nil {
+		return Transfer{}, err
+	}
+
+	fields := parseFields(string(msg))
+	amount, err := strconv.Atoi(fields["amount"])
+	if err != nil {
+		return Transfer{}, fmt.Errorf("malformed amount %q", fields["amount"])
+	}
+	return Transfer{From: fields["from"], To: fields["to"], Amount: amount}, nil
+}
+
+// SignTransactions is the client side of the second protocol: it creates
+// the request message || MAC for a list of transfers from the given account,
+// using a fixed zero IV.
+func (s *APIServer) SignTransactions(from string, txs []Transfer) ([]byte, error) {
+	var list []string
+	for _, tx := range txs {
+		list = append(list, fmt.Sprintf("%v:%v", sanitize(tx.To), tx.Amount))
+	}
+	msg := fmt.Sprintf("from=%v&tx_list=%v", sanitize(from), strings.Join(list, ";"))
+	mac, err := pals.CBCMAC(s.key, make([]byte, keySize), []byte(msg))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(msg), mac...), nil
+}
+
+// VerifyTransactions verifies and parses a request message || MAC.
+// Like many real parsers it is lenient and skips transactions
+// it can't make sense of.
+func (s *APIServer) VerifyTransactions(req []byte) ([]Transfer, error) {
+	if len(req) < keySize {
+		return nil, fmt.Errorf("request too short")
+	}
+	msg := req[:len(req)-keySize]
+	if err := s.checkMAC(make([]byte, keySize), msg, req[len(req)-keySize:]); err != nil {
+		return nil, err
+	}
+
+	// tx_list is the last field, so everything after it belongs to it
+	parts := strings.SplitN(string(msg), "&", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "from=") || !strings.HasPrefix(parts[1], "tx_list=") {
+		return nil, fmt.Errorf("malformed transaction request")
+	}
+	from := strings.TrimPrefix(parts[0], "from=")
+	list := strings.TrimPrefix(parts[1], "tx_list=")
+
+	var txs []Transfer
+	for _, tx := range strings.Split(list, ";") {
+		kv := strings.SplitN(tx, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		amount, err := strconv.Atoi(kv[1])
+		if err != nil {
+			continue
+		}
+		txs = append(txs, Transfer{From: from, To: kv[0], Amount: amount})
+	}
+	return txs, nil
+}
+
+func (s *APIServer) checkMAC(iv, msg, mac []byte) error {
+	expected, err := pals.CBCMAC(s.key, iv, msg)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(expected, mac) != 1 {
+		return fmt.Errorf("invalid MAC")
+	}
+	return nil
+}
+
+// parseFields parses a k1=v1&k2=v2 string, skipping malformed pairs
+func parseFields(msg string) map[string]string {
+	fields := make(map[string]string)
+	for _, p := range strings.Split(msg, "&") {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	return fields
+}
+
+// C49 solution
+func C49() {
+	fmt.Println("---------------------- c49 ------------------------")
+	const victim, attacker = "1337", "6666"
+
+	server, err := NewAPIServer()
+	if err != nil {
+		log.Fatalf("failed to start server: %v", err)
+	}
+
+	// Scenario 1: attacker controlled IV
+	forged, err := ForgeTransferIV(server, victim, attacker, 1000000)
+	if err != nil {
+		log.Fatalf("failed to forge transfer: %v", err)
+	}
+	tx, err := server.VerifyTransfer(forged)
+	if err != nil {
+		log.Fatalf("server rejected forged transfer: %v", err)
+	}
+	fmt.Printf("Server accepted forged transfer: %+v\n", tx)
+
+	// Scenario 2: fixed IV, length extension of a captured message
+	captured, err := server.SignTransactions(victim, []Transfer{{To: "42", Amount: 10}, {To: "7", Amount: 20}})
+	if err != nil {
+		log.Fatalf("failed to sign victim transactions: %v", err)
+	}
+	forged, err = ExtendTransactions(server, captured, attacker, 1000000)
+	if err != nil {
+		log.Fatalf("failed to extend transactions: %v", err)
+	}
+	txs, err := server.VerifyTransactions(forged)
+	if err != nil {
+		log.Fatalf("server rejected forged transactions: %v", err)
+	}
+	fmt.Printf("Server accepted forged transactions: %+v\n", txs)
+}
+
+// ForgeTransferIV forges a transfer from the victim's account to the
+// attacker, given an attacker controlled IV. The ids must be of equal length.
+//
+// The attacker signs a legitimate transfer from their own account. The first
+// plaintext block is XORed with the IV before encryption, so flipping bytes
+// of the IV flips the same bytes of the first block without changing the MAC.
+func ForgeTransferIV(server *APIServer, victim, attacker string, amount int) ([]byte, error) {
+	if len(victim) != len(attacker) {
+		return nil, fmt.Errorf("victim and attacker ids must have equal length")
+	}
+	req, err := server.SignTransfer(attacker, attacker, amount)
+	if err != nil {
+		return nil, err
+	}
+	msg := req[:len(req)-2*keySize]
+	iv := req[len(req)-2*keySize : len(req)-keySize]
+	mac := req[len(req)-keySize:]
+
+	wanted := []byte(fmt.Sprintf("from=%v&to=%v&amount=%v", victim, attacker, amount))
+	if len(wanted) < keySize || !bytes.Equal(wanted[keySize:], msg[keySize:]) {
+		return nil, fmt.Errorf("victim id must only change the first block")
+	}
+	newIV := pals.XorFixed(iv, pals.XorFixed(msg[:keySize], wanted[:keySize]))
+
+	out := append(wanted, newIV...)
+	return append(out, mac...), nil
+}
+
+// ExtendTransactions appends a transfer to the attacker onto a captured
+// transaction list of the victim.
+//
+// With a fixed IV, the MAC t of the victim's message M is the CBC state after
+// processing M. A message M' from the attacker's own account starts from a
+// zero state, so M || pad || (M'[0] ^ t) || M'[1:] has the same MAC as M'.
+// The glue block is garbage, but the lenient server skips transactions it
+// can't parse. M' is built so that the transfer to the attacker starts on a
+// block boundary.
+func ExtendTransactions(server *APIServer, captured []byte, attacker string, amount int) ([]byte, error) {
+	if len(captured) < keySize {
+		return nil, fmt.Errorf("captured request too short")
+	}
+	msg := captured[:len(captured)-keySize]
+	t := captured[len(captured)-keySize:]
+
+	// Add a filler transaction so that ";attacker:amount" starts on a
+	// block boundary after the glue
+	prefix := len("from=" + attacker + "&tx_list=" + ":1")
+	filler := Transfer{To: strings.Repeat("0", keySize-prefix%keySize), Amount: 1}
+	own, err := server.SignTransactions(attacker, []Transfer{filler, {To: attacker, Amount: amount}})
+	if err != nil {
+		return nil, err
+	}
+	ownMsg := own[:len(own)-keySize]
+	ownMAC := own[len(own)-keySize:]
+
+	out := pals.PadPKCS7(msg, keySize)
+	out = append(out, pals.XorFixed(ownMsg[:keySize], t)...)
+	out = append(out, ownMsg[keySize:]...)
+	return append(out, ownMAC...), nil
+}
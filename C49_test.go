@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestForgeTransferIV(t *testing.T) {
+	ex := []struct {
+		victim, attacker string
+		amount           int
+	}{
+		{"1337", "6666", 1000000},
+		{"1", "2", 5},
+		{"alice", "mallo", 42},
+	}
+
+	server, err := NewAPIServer()
+	if err != nil {
+		t.Fatalf("failed to start server: %v", err)
+	}
+	for _, e := range ex {
+		forged, err := ForgeTransferIV(server, e.victim, e.attacker, e.amount)
+		if err != nil {
+			t.Fatalf("forgery failed: %v", err)
+		}
+		tx, err := server.VerifyTransfer(forged)
+		if err != nil {
+			t.Fatalf("server rejected forgery: %v", err)
+		}
+		exp := Transfer{From: e.victim, To: e.attacker, Amount: e.amount}
+		if tx != exp {
+			t.Errorf("Forgery failed: \nExp: %+v \nGot: %+v", exp, tx)
+		}
+	}
+
+	// tampering without fixing up the IV must be detected
+	req, _ := server.SignTransfer("6666", "6666", 1)
+	req[len("from=")] = '1'
+	if _, err := server.VerifyTransfer(req); err == nil {
+		t.Errorf("Server accepted tampered transfer")
+	}
+}
+
+func TestExtendTransactions(t *testing.T) {
+	ex := []struct {
+		victim, attacker string
+		txs              []Transfer
+	}{
+		{"1337", "6666", []Transfer{{To: "42", Amount: 10}, {To: "7", Amount: 20}}},
+		{"1", "2", []Transfer{{To: "3", Amount: 1}}},
+		{"alice", "mallory", []Transfer{{To: "bob", Amount: 100}, {To: "carol", Amount: 5}, {To: "dave", Amount: 9}}},
+	}
+
+	server, err := NewAPIServer()
+	if err != nil {
+		t.Fatalf("failed to start server: %v", err)
+	}
+	for _, e := range ex {
+		captured, err := server.SignTransactions(e.victim, e.txs)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		forged, err := ExtendTransactions(server, captured, e.attacker, 1000000)
+		if err != nil {
+			t.Fatalf("extension failed: %v", err)
+		}
+		txs, err := server.VerifyTransactions(forged)
+		if err != nil {
+			t.Fatalf("server rejected forgery: %v", err)
+		}
+		last := txs[len(txs)-1]
+		exp := Transfer{From: e.victim, To: e.attacker, Amount: 1000000}
+		if last != exp {
+			t.Errorf("Extension failed: \nExp: %+v \nGot: %+v", exp, txs)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"fmt"
+	"log"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+const (
+	c50Key    = "YELLOW SUBMARINE"
+	c50Target = "alert('MZA who was that?');\n"
+	c50Prefix = "alert('Ayo, the Wu is back!');//"
+)
+
+// C50 solution
+func C50() {
+	fmt.Println("---------------------- c50 ------------------------")
+	target, err := jsHash([]byte(c50Target))
+	if err != nil {
+		log.Fatalf("failed to hash target: %v", err)
+	}
+	fmt.Printf("Target %q hashes to %x\n", c50Target, target)
+
+	forged, err := ForgeCBCMACCollision([]byte(c50Key), []byte(c50Target), []byte(c50Prefix), 1<<28)
+	if err != nil {
+		log.Fatalf("failed to forge collision: %v", err)
+	}
+	hash, err := jsHash(forged)
+	if err != nil {
+		log.Fatalf("failed to hash forgery: %v", err)
+	}
+	fmt.Printf("Forgery %q hashes to %x (collision? %v)\n", forged, hash, bytes.Equal(hash, target))
+}
+
+// jsHash is the CBC-MAC based hash used to check JavaScript snippets
+func jsHash(snippet []byte) ([]byte, error) {
+	return pals.CBCMAC([]byte(c50Key), make([]byte, keySize), snippet)
+}
+
+// ForgeCBCMACCollision creates a message starting with prefix that has the
+// same zero IV CBC-MAC under key as target.
+//
+// The prefix is padded to a block boundary, followed by a counter block and
+// a glue block G = state XOR target[0], where state is the CBC state after the
+// prefix and counter. Processing G then leaves the same state as processing
+// target[0] from a zero IV, so appending target[1:] yields the same MAC.
+// The counter is varied up to maxTries times to find a glue block that is
+// printable ASCII, so that it can hide in a comment. If none is found, the
+// first glue block without line terminators is used instead.
+func ForgeCBCMACCollision(key, target, prefix []byte, maxTries int) ([]byte, error) {
+	cypher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate cypher with key %v: %v", key, err)
+	}
+	bs := cypher.BlockSize()
+	if len(target) < bs {
+		return nil, fmt.Errorf("target must be at least one block long")
+	}
+
+	// Pad the prefix with spaces and get the CBC state after it
+	head := append([]byte{}, prefix...)
+	for len(head)%bs != 0 {
+		head = append(head, ' ')
+	}
+	state := make([]byte, len(head))
+	pals.NewCBCEncrypter(cypher, make([]byte, bs)).CryptBlocks(state, head)
+	if len(state) > 0 {
+		state = state[len(state)-bs:]
+	} else {
+		state = make([]byte, bs)
+	}
+
+	var fallback []byte
+	counter := make([]byte, bs)
+	glue := make([]byte, bs)
+	for i := 0; i < maxTries; i++ {
+		copy(counter, fmt.Sprintf("%0*x", bs, i))
+		cypher.Encrypt(glue, pals.XorFixed(state, counter))
+		for j := range glue {
+			glue[j] ^= target[j]
+		}
+
+		if isPrintable(glue) {
+			return bytes.Join([][]byte{head, counter, glue, target[bs:]}, nil), nil
+		}
+		if fallback == nil && !bytes.ContainsAny(glue, "\r\n") {
+			fallback = bytes.Join([][]byte{head, counter, glue, target[bs:]}, nil)
+		}
+	}
+	if fallback == nil {
+		return nil, fmt.Errorf("no usable glue block found in %v tries", maxTries)
+	}
+	return fallback, nil
+}
+
+// isPrintable reports whether b consists only of printable ASCII
+func isPrintable(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestForgeCBCMACCollision(t *testing.T) {
+	ex := []struct {
+		target    string
+		prefix    string
+		maxTries  int
+		printable bool
+	}{
+		{c50Target, c50Prefix, 1000, false},
+		{c50Target, "", 1000, false},
+		{"alert('We all live in a yellow submarine');\n", "alert('hi mom'); //", 1000, false},
+		{c50Target, c50Prefix, 1 << 28, true},
+	}
+
+	for _, e := range ex {
+		if e.printable && testing.Short() {
+			continue
+		}
+		target, err := jsHash([]byte(e.target))
+		if err != nil {
+			t.Fatalf("failed to hash target: %v", err)
+		}
+		forged, err := ForgeCBCMACCollision([]byte(c50Key), []byte(e.target), []byte(e.prefix), e.maxTries)
+		if err != nil {
+			t.Fatalf("forgery failed: %v", err)
+		}
+		hash, err := jsHash(forged)
+		if err != nil {
+			t.Fatalf("failed to hash forgery: %v", err)
+		}
+		if !bytes.Equal(hash, target) {
+			t.Errorf("Forgery %q doesn't collide: \nExp: %x \nGot: %x", forged, target, hash)
+		}
+		if !bytes.HasPrefix(forged, []byte(e.prefix)) {
+			t.Errorf("Forgery %q doesn't start with %q", forged, e.prefix)
+		}
+		// the glue has to stay inside the comment
+		if bytes.ContainsAny(forged[:len(forged)-len(e.target)+keySize], "\r\n") {
+			t.Errorf("Forgery %q contains a line break before the target's tail", forged)
+		}
+		if e.printable && !isPrintable(forged[:len(forged)-len(e.target)+keySize]) {
+			t.Errorf("Forgery %q is not printable", forged)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+const (
+	c51Session = "TmV2ZXIgcmV2ZWFsIHRoZSBXdS1UYW5nIFNlY3JldCE="
+	// base64 alphabet plus the carriage return that ends the cookie header
+	c51Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/=\r"
+	// terminates each guess; without it, deflate's handling of the end of
+	// input can hide the difference a single correct character makes
+	c51Suffix = "~|"
+)
+
+// CompressionOracle builds an HTTP request around attacker supplied content,
+// compresses and encrypts it under a fresh key, and reveals only the length
+type CompressionOracle struct {
+	session string
+	mode    CryptMode
+
+	mu sync.Mutex
+	fw *flate.Writer
+}
+
+// NewCompressionOracle creates an oracle that encrypts in CTR or CBC mode
+// and embeds the given session id in a cookie
+func NewCompressionOracle(session string, mode CryptMode) (*CompressionOracle, error) {
+	if mode != CTRMode && mode != CBCMode {
+		return nil, fmt.Errorf("unsupported mode %v", mode)
+	}
+	fw, err := flate.NewWriter(nil, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+	return &CompressionOracle{session: session, mode: mode, fw: fw}, nil
+}
+
+// Length returns the length of the compressed and encrypted request
+// carrying the given body
+func (o *CompressionOracle) Length(body []byte) (int, error) {
+	req := fmt.Sprintf("POST / HTTP/1.1\r\nHost: hapless.com\r\nCookie: sessionid=%v\r\nContent-Length: %v\r\n%s",
+		o.session, len(body), body)
+
+	var buf bytes.Buffer
+	o.mu.Lock()
+	o.fw.Reset(&buf)
+	_, err := o.fw.Write([]byte(req))
+	if err == nil {
+		err = o.fw.Close()
+	}
+	o.mu.Unlock()
+	if err != nil {
+		return 0, fmt.Errorf("failed to compress request: %v", err)
+	}
+
+	key, err := pals.GenerateRandomBytes(keySize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate key: %v", err)
+	}
+	iv, err := pals.GenerateRandomBytes(keySize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate IV: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to instantiate cypher: %v", err)
+	}
+
+	var crypt []byte
+	if o.mode == CTRMode {
+		crypt = make([]byte, buf.Len())
+		cipher.NewCTR(block, iv).XORKeyStream(crypt, buf.Bytes())
+	} else {
+		plain := pals.PadPKCS7(buf.Bytes(), keySize)
+		crypt = make([]byte, len(plain))
+		pals.NewCBCEncrypter(block, iv).CryptBlocks(crypt, plain)
+	}
+	return len(crypt), nil
+}
+
+// C51 solution
+func C51() {
+	fmt.Println("---------------------- c51 ------------------------")
+	rng := rand.New(rand.NewSource(51))
+	for _, mode := range []CryptMode{CTRMode, CBCMode} {
+		oracle, err := NewCompressionOracle(c51Session, mode)
+		if err != nil {
+			log.Fatalf("failed to create oracle: %v", err)
+		}
+		session, err := CompressionAttack(oracle, "sessionid=", 64, rng)
+		if err != nil {
+			log.Fatalf("attack failed: %v", err)
+		}
+		fmt.Printf("Mode %v: recovered session id %q\n", mode, session)
+	}
+}
+
+// CompressionAttack recovers the value following the known prefix in the
+// request, one character at a time (CRIME).
+//
+// Sending prefix + known + guess compresses better when the guess is right,
+// since the whole string then repeats the secret. In CBC mode the length is
+// quantized to blocks, so each guess is tried behind filler of varying
+// length: at the right filler length the wrong guesses just spill over
+// into an extra block while the right one doesn't. Guesses that produce the
+// shortest output for a filler get a vote, and rounds with fresh random
+// filler, drawn from rng, are repeated until one guess clearly leads.
+func CompressionAttack(oracle *CompressionOracle, prefix string, maxLen int, rng *rand.Rand) (string, error) {
+	var known []byte
+	for len(known) < maxLen {
+		c, err := guessNext(oracle, prefix+string(known), rng)
+		if err != nil {
+			return string(known), err
+		}
+		if c == '\r' {
+			return string(known), nil
+		}
+		known = append(known, c)
+	}
+	return string(known), fmt.Errorf("no end of value found after %v characters", maxLen)
+}
+
+// guessNext finds the character most likely to follow known in the request
+func guessNext(oracle *CompressionOracle, known string, rng *rand.Rand) (byte, error) {
+	const maxRounds = 32
+	votes := make([]int, len(c51Alphabet))
+	lengths := make([]int, len(c51Alphabet))
+
+	for round := 0; round < maxRounds; round++ {
+		// filler bytes may be encoded in less than a byte each, so go up to
+		// two blocks to be sure to hit every block boundary
+		filler := randomFiller(2*keySize, rng)
+		for l := 0; l < len(filler); l++ {
+			min := -1
+			for i := range c51Alphabet {
+				body := filler[:l] + known + string(c51Alphabet[i]) + c51Suffix
+				n, err := oracle.Length([]byte(body))
+				if err != nil {
+					return 0, err
+				}
+				lengths[i] = n
+				if min < 0 || lengths[i] < min {
+					min = lengths[i]
+				}
+			}
+			// only count fillers that actually separate the guesses
+			winners := 0
+			for i := range lengths {
+				if lengths[i] == min {
+					winners++
+				}
+			}
+			if winners == len(lengths) {
+				continue
+			}
+			for i := range lengths {
+				if lengths[i] == min {
+					votes[i]++
+				}
+			}
+		}
+
+		best, second := -1, -1
+		for i := range votes {
+			if best < 0 || votes[i] > votes[best] {
+				best, second = i, best
+			} else if second < 0 || votes[i] > votes[second] {
+				second = i
+			}
+		}
+		if votes[best] > 0 && votes[best] >= 2*votes[second]+2 {
+			return c51Alphabet[best], nil
+		}
+	}
+	return 0, fmt.Errorf("no clear winner for character after %q", known)
+}
+
+// randomFiller returns n random non-ASCII filler bytes without repeats,
+// so that the filler neither occurs in the request nor compresses itself
+func randomFiller(n int, rng *rand.Rand) string {
+	perm := rng.Perm(0x80)
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = byte(0x80 + perm[i])
+	}
+	return string(out)
+}
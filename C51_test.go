@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/base64"
+	"math/rand"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+func TestCompressionAttack(t *testing.T) {
+	random, err := pals.GenerateRandomBytes(20)
+	if err != nil {
+		t.Fatalf("failed to generate session: %v", err)
+	}
+	ex := []struct {
+		session string
+		mode    CryptMode
+	}{
+		{c51Session, CTRMode},
+		{c51Session, CBCMode},
+		{base64.StdEncoding.EncodeToString(random), CTRMode},
+		{base64.StdEncoding.EncodeToString(random), CBCMode},
+	}
+
+	for _, e := range ex {
+		oracle, err := NewCompressionOracle(e.session, e.mode)
+		if err != nil {
+			t.Fatalf("failed to create oracle: %v", err)
+		}
+		result, err := CompressionAttack(oracle, "sessionid=", 64, rand.New(rand.NewSource(51)))
+		if err != nil {
+			t.Fatalf("attack (mode: %v) failed after %q: %v", e.mode, result, err)
+		}
+		if result != e.session {
+			t.Errorf("Compression attack (mode: %v) failed: \nExp: %v \nGot: %v", e.mode, e.session, result)
+		}
+	}
+
+	if _, err := NewCompressionOracle(c51Session, ECBMode); err == nil {
+		t.Errorf("Creating an ECB oracle should fail")
+	}
+}
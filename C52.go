@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+// Collision is a pair of distinct single blocks that collide
+// from a common starting state
+type Collision struct {
+	A, B []byte
+}
+
+// C52 solution
+func C52() {
+	fmt.Println("---------------------- c52 ------------------------")
+	f, err := pals.NewMDHash(16)
+	if err != nil {
+		log.Fatalf("failed to create cheap hash: %v", err)
+	}
+	g, err := pals.NewMDHash(24)
+	if err != nil {
+		log.Fatalf("failed to create expensive hash: %v", err)
+	}
+
+	colls, _ := Multicollision(f, f.IV, 4)
+	msgs := ExpandMulticollision(colls)
+	fmt.Printf("Generated %v messages colliding under f:\n", len(msgs))
+	for _, m := range msgs[:4] {
+		fmt.Printf("\tf(%x...) = %x\n", m[:8], f.Sum(m))
+	}
+
+	a, b, calls, err := CascadeCollision(f, g)
+	if err != nil {
+		log.Fatalf("failed to find cascade collision: %v", err)
+	}
+	fmt.Printf("Found f||g collision after %v compression calls:\n", calls)
+	fmt.Printf("\tf||g(%x) = %x%x\n", a, f.Sum(a), g.Sum(a))
+	fmt.Printf("\tf||g(%x) = %x%x\n", b, f.Sum(b), g.Sum(b))
+}
+
+// FindCollision finds two distinct blocks that collide under h when
+// starting from state, using a birthday search. The colliding blocks
+// and the resulting state are returned, together with the number of
+// compression function calls made.
+func FindCollision(h *pals.MDHash, state []byte) (Collision, []byte, int) {
+	seen := make(map[string][]byte)
+	calls := 0
+	for {
+		block, err := pals.GenerateRandomBytes(pals.MDBlockSize)
+		if err != nil {
+			log.Fatalf("failed to generate block: %v", err)
+		}
+		next := h.Compress(state, block)
+		calls++
+		if prev, ok := seen[string(next)]; ok && !bytes.Equal(prev, block) {
+			return Collision{A: prev, B: block}, next, calls
+		}
+		seen[string(next)] = block
+	}
+}
+
+// Multicollision generates n successive single block collisions starting
+// from state (Joux). Choosing either block of each collision gives 2^n
+// distinct n block messages that all end in the same state.
+// Returns the collisions and the number of compression function calls.
+func Multicollision(h *pals.MDHash, state []byte, n int) ([]Collision, int) {
+	var colls []Collision
+	calls := 0
+	for i := 0; i < n; i++ {
+		c, next, k := FindCollision(h, state)
+		colls = append(colls, c)
+		calls += k
+		state = next
+	}
+	return colls, calls
+}
+
+// ExpandMulticollision lists all 2^n messages of a multicollision
+func ExpandMulticollision(colls []Collision) [][]byte {
+	msgs := [][]byte{{}}
+	for _, c := range colls {
+		var next [][]byte
+		for _, m := range msgs {
+			next = append(next, append(append([]byte{}, m...), c.A...))
+			next = append(next, append(append([]byte{}, m...), c.B...))
+		}
+		msgs = next
+	}
+	return msgs
+}
+
+// CascadeCollision finds a collision in f(m) || g(m), where f is the
+// cheaper hash.
+//
+// A 2^(b/2) multicollision in f, where b is g's state size in bits,
+// contains enough messages that a birthday collision in g among them is
+// likely. If not, the multicollision is extended and we try again.
+// Returns the two messages and the number of compression function calls.
+func CascadeCollision(f, g *pals.MDHash) (a, b []byte, calls int, err error) {
+	n := g.Size * 8 / 2
+	colls, calls := Multicollision(f, f.IV, n)
+	fState := f.Iterate(f.IV, collisionPath(colls, 0))
+
+	for len(colls) < 2*g.Size*8 {
+		// Run g over the multicollision tree one layer at a time,
+		// keeping track of which path leads to which state
+		states := map[string]uint64{string(g.IV): 0}
+		for i, c := range colls {
+			next := make(map[string]uint64, 2*len(states))
+			for s, path := range states {
+				for bit, block := range [][]byte{c.A, c.B} {
+					st := g.Compress([]byte(s), block)
+					calls++
+					p := path | uint64(bit)<<uint(i)
+					if prev, ok := next[string(st)]; ok && prev != p {
+						a, b = collisionPath(colls[:i+1], prev), collisionPath(colls[:i+1], p)
+						// collisions in the truncated path remain collisions
+						// when both are padded the same way
+						return a, b, calls, nil
+					}
+					next[string(st)] = p
+				}
+			}
+			states = next
+		}
+
+		// No luck, extend the multicollision by one and retry
+		c, next, k := FindCollision(f, fState)
+		colls = append(colls, c)
+		calls += k
+		fState = next
+	}
+	return nil, nil, calls, fmt.Errorf("no collision found in a 2^%v multicollision", len(colls))
+}
+
+// collisionPath builds the message that picks B for every set bit of path
+func collisionPath(colls []Collision, path uint64) []byte {
+	var out []byte
+	for i, c := range colls {
+		if path&(1<<uint(i)) != 0 {
+			out = append(out, c.B...)
+		} else {
+			out = append(out, c.A...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+func TestMulticollision(t *testing.T) {
+	for _, n := range []int{1, 3, 6} {
+		h, err := pals.NewMDHash(16)
+		if err != nil {
+			t.Fatalf("failed to create hash: %v", err)
+		}
+		colls, _ := Multicollision(h, h.IV, n)
+		msgs := ExpandMulticollision(colls)
+		if len(msgs) != 1<<uint(n) {
+			t.Fatalf("Multicollision of size %v gave %v messages", n, len(msgs))
+		}
+
+		seen := make(map[string]bool)
+		expected := h.Sum(msgs[0])
+		for _, m := range msgs {
+			if seen[string(m)] {
+				t.Errorf("Multicollision of size %v has duplicate message %x", n, m)
+			}
+			seen[string(m)] = true
+			if got := h.Sum(m); !bytes.Equal(got, expected) {
+				t.Errorf("Multicollision of size %v failed: \nExp: %x \nGot: %x", n, expected, got)
+			}
+		}
+	}
+}
+
+func TestCascadeCollision(t *testing.T) {
+	for _, bits := range []int{24, 32} {
+		f, err := pals.NewMDHash(16)
+		if err != nil {
+			t.Fatalf("failed to create cheap hash: %v", err)
+		}
+		g, err := pals.NewMDHash(bits)
+		if err != nil {
+			t.Fatalf("failed to create expensive hash: %v", err)
+		}
+		a, b, _, err := CascadeCollision(f, g)
+		if err != nil {
+			t.Fatalf("cascade collision (bits: %v) failed: %v", bits, err)
+		}
+		if bytes.Equal(a, b) {
+			t.Errorf("Cascade collision (bits: %v) returned identical messages", bits)
+		}
+		if !bytes.Equal(f.Sum(a), f.Sum(b)) || !bytes.Equal(g.Sum(a), g.Sum(b)) {
+			t.Errorf("Cascade collision (bits: %v) failed: \nf: %x vs %x \ng: %x vs %x", bits, f.Sum(a), f.Sum(b), g.Sum(a), g.Sum(b))
+		}
+	}
+}
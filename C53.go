@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math/rand"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+// ExpandableMessage is a (k, k + 2^k - 1) expandable message: a set of k
+// colliding pairs that can be combined into a message of any length between
+// k and k + 2^k - 1 blocks, all ending in the same state.
+type ExpandableMessage struct {
+	// Short holds the single block piece for each level
+	Short [][]byte
+	// Long holds the 2^(k-1-i) + 1 block piece for each level i
+	Long [][]byte
+	// State is the state after any message produced
+	State []byte
+}
+
+// C53 solution
+func C53() {
+	fmt.Println("---------------------- c53 ------------------------")
+	const k = 16
+	rng := rand.New(rand.NewSource(53))
+
+	h, err := pals.NewMDHash(24)
+	if err != nil {
+		log.Fatalf("failed to create hash: %v", err)
+	}
+	var msg []byte
+	for i := 0; i < 1<<k; i++ {
+		msg = append(msg, randomBlock(rng)...)
+	}
+
+	forged, err := SecondPreimage(h, msg, k, rng)
+	if err != nil {
+		log.Fatalf("second preimage attack failed: %v", err)
+	}
+	fmt.Printf("Original: %v bytes, hash %x\n", len(msg), h.Sum(msg))
+	fmt.Printf("Forgery:  %v bytes, hash %x (differs? %v)\n", len(forged), h.Sum(forged), !bytes.Equal(msg, forged))
+}
+
+// NewExpandableMessage builds a (k, k + 2^k - 1) expandable message for h
+// starting from state.
+//
+// At level i we find a collision between a single block and a message of
+// 2^(k-1-i) dummy blocks followed by one block. Picking the short or long
+// piece at each level adds 0 or 2^(k-1-i) blocks to the length.
+func NewExpandableMessage(h *pals.MDHash, state []byte, k int, rng *rand.Rand) *ExpandableMessage {
+	e := &ExpandableMessage{}
+	dummy := randomBlock(rng)
+	for i := 0; i < k; i++ {
+		n := 1 << uint(k-1-i)
+		prefix := bytes.Repeat(dummy, n)
+		longState := h.Iterate(state, prefix)
+
+		short, last, next := findCrossCollision(h, state, longState, rng)
+		e.Short = append(e.Short, short)
+		e.Long = append(e.Long, append(prefix, last...))
+		state = next
+	}
+	e.State = state
+	return e
+}
+
+// Produce returns a message of exactly the given number of blocks
+func (e *ExpandableMessage) Produce(blocks int) ([]byte, error) {
+	k := len(e.Short)
+	extra := blocks - k
+	if extra < 0 || extra >= 1<<uint(k) {
+		return nil, fmt.Errorf("can only produce %v to %v blocks, not %v", k, k+1<<uint(k)-1, blocks)
+	}
+	var out []byte
+	for i := 0; i < k; i++ {
+		if extra&(1<<uint(k-1-i)) != 0 {
+			out = append(out, e.Long[i]...)
+		} else {
+			out = append(out, e.Short[i]...)
+		}
+	}
+	return out, nil
+}
+
+// SecondPreimage finds a different message of the same length as msg with
+// the same digest (Kelsey-Schneier). msg should be about 2^k blocks long.
+//
+// Map every intermediate state of msg to its position, then find a bridge
+// block from the end of an expandable message into one of them. Expanding
+// the message to fill the blocks before the bridge, and appending the rest
+// of msg, gives the same length and therefore the same padding and digest.
+func SecondPreimage(h *pals.MDHash, msg []byte, k int, rng *rand.Rand) ([]byte, error) {
+	blocks := pals.ChunkBytes(msg, pals.MDBlockSize)
+
+	// states reachable by the expandable message: after k+1 to k+2^k blocks
+	states := make(map[string]int)
+	state := h.IV
+	for i, b := range blocks {
+		state = h.Compress(state, b)
+		if n := i + 1; n > k && n <= k+1<<uint(k) {
+			states[string(state)] = n
+		}
+	}
+	if len(states) == 0 {
+		return nil, fmt.Errorf("message of %v blocks too short for k = %v", len(blocks), k)
+	}
+
+	e := NewExpandableMessage(h, h.IV, k, rng)
+	for tries := 0; tries < 1<<uint(8*h.Size+2); tries++ {
+		bridge := randomBlock(rng)
+		n, ok := states[string(h.Compress(e.State, bridge))]
+		if !ok {
+			continue
+		}
+		prefix, err := e.Produce(n - 1)
+		if err != nil {
+			return nil, err
+		}
+		out := append(prefix, bridge...)
+		return append(out, msg[n*pals.MDBlockSize:]...), nil
+	}
+	return nil, fmt.Errorf("no bridge block found")
+}
+
+// findCrossCollision finds single blocks x and y such that compressing x
+// from s1 and y from s2 gives the same state, which is returned as well
+func findCrossCollision(h *pals.MDHash, s1, s2 []byte, rng *rand.Rand) (x, y, state []byte) {
+	left := make(map[string][]byte)
+	right := make(map[string][]byte)
+	for {
+		a := randomBlock(rng)
+		sa := string(h.Compress(s1, a))
+		if b, ok := right[sa]; ok {
+			return a, b, []byte(sa)
+		}
+		left[sa] = a
+
+		b := randomBlock(rng)
+		sb := string(h.Compress(s2, b))
+		if a, ok := left[sb]; ok {
+			return a, b, []byte(sb)
+		}
+		right[sb] = b
+	}
+}
+
+// randomBlock returns a block of pseudo random bytes from rng
+func randomBlock(rng *rand.Rand) []byte {
+	b := make([]byte, pals.MDBlockSize)
+	for i := 0; i < len(b); i += 8 {
+		binary.LittleEndian.PutUint64(b[i:], rng.Uint64())
+	}
+	return b
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+func TestExpandableMessage(t *testing.T) {
+	const k = 6
+	rng := rand.New(rand.NewSource(1))
+	h, err := pals.NewMDHash(16)
+	if err != nil {
+		t.Fatalf("failed to create hash: %v", err)
+	}
+
+	e := NewExpandableMessage(h, h.IV, k, rng)
+	for n := k; n < k+1<<k; n++ {
+		msg, err := e.Produce(n)
+		if err != nil {
+			t.Fatalf("failed to produce %v blocks: %v", n, err)
+		}
+		if len(msg) != n*pals.MDBlockSize {
+			t.Errorf("Produced message has wrong length: Expected: %v blocks Got: %v bytes", n, len(msg))
+		}
+		if state := h.Iterate(h.IV, msg); !bytes.Equal(state, e.State) {
+			t.Errorf("Produced message of %v blocks ends in wrong state: \nExp: %x \nGot: %x", n, e.State, state)
+		}
+	}
+	for _, n := range []int{k - 1, k + 1<<k} {
+		if _, err := e.Produce(n); err == nil {
+			t.Errorf("Producing %v blocks should fail", n)
+		}
+	}
+}
+
+func TestSecondPreimage(t *testing.T) {
+	ex := []struct {
+		bits int
+		k    int
+		tail int // bytes after the last full block
+		seed int64
+	}{
+		{16, 8, 0, 1},
+		{16, 10, 5, 2},
+		{24, 10, 0, 3},
+	}
+
+	for _, e := range ex {
+		rng := rand.New(rand.NewSource(e.seed))
+		h, err := pals.NewMDHash(e.bits)
+		if err != nil {
+			t.Fatalf("failed to create hash: %v", err)
+		}
+		var msg []byte
+		for i := 0; i < 1<<uint(e.k); i++ {
+			msg = append(msg, randomBlock(rng)...)
+		}
+		msg = append(msg, randomBlock(rng)[:e.tail]...)
+
+		forged, err := SecondPreimage(h, msg, e.k, rng)
+		if err != nil {
+			t.Fatalf("second preimage (bits: %v, k: %v) failed: %v", e.bits, e.k, err)
+		}
+		if bytes.Equal(forged, msg) || len(forged) != len(msg) {
+			t.Errorf("Second preimage (bits: %v, k: %v) not a distinct same length message", e.bits, e.k)
+		}
+		if !bytes.Equal(h.Sum(forged), h.Sum(msg)) {
+			t.Errorf("Second preimage (bits: %v, k: %v) failed: \nExp: %x \nGot: %x", e.bits, e.k, h.Sum(msg), h.Sum(forged))
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+// Diamond is a diamond structure for herding: a binary tree of collisions
+// funnelling 2^k leaf states into a single root state.
+type Diamond struct {
+	// States holds the states at each level, from 2^k leaves to the root
+	States [][][]byte
+	// Blocks[i][j] leads from States[i][j] to States[i+1][j/2]
+	Blocks [][][]byte
+}
+
+// Commitment is the digest that predictions herded through a diamond hash
+// to. It only holds for predictions with PrefixBlocks blocks of prefix.
+type Commitment struct {
+	Digest       []byte
+	PrefixBlocks int
+}
+
+// C54 solution
+func C54() {
+	fmt.Println("---------------------- c54 ------------------------")
+	const k = 8
+	// the number of blocks our prediction will have before the glue block
+	const prefixBlocks = 4
+	rng := rand.New(rand.NewSource(54))
+
+	h, err := pals.NewMDHash(24)
+	if err != nil {
+		log.Fatalf("failed to create hash: %v", err)
+	}
+	d := BuildDiamond(h, k, rng)
+	commitment := d.Commit(h, prefixBlocks)
+	fmt.Printf("Committed to hash %x before the season starts\n", commitment.Digest)
+
+	// ... and after the season, make a perfect prediction
+	scores := padPrediction("Cubs 4, Sox 3; Mets 2, Yankees 9; Giants 7, Dodgers 5", prefixBlocks)
+	prediction, err := d.Predict(h, commitment, scores, rng)
+	if err != nil {
+		log.Fatalf("failed to herd prediction: %v", err)
+	}
+	fmt.Printf("Prediction %q... hashes to %x (matches? %v)\n", prediction[:len(scores)], h.Sum(prediction), bytes.Equal(h.Sum(prediction), commitment.Digest))
+}
+
+// padPrediction pads the prediction with spaces to the given number of blocks
+func padPrediction(s string, blocks int) []byte {
+	if len(s) > blocks*pals.MDBlockSize {
+		s = s[:blocks*pals.MDBlockSize]
+	}
+	return []byte(s + strings.Repeat(" ", blocks*pals.MDBlockSize-len(s)))
+}
+
+// BuildDiamond builds a diamond structure of width 2^k for h.
+//
+// Starting with 2^k random leaf states, states are paired up and a pair of
+// blocks leading both to a common state is found for each pair, halving the
+// number of states at each level. The collision searches on a level are
+// independent, so they run in parallel. Each search gets its own seed drawn
+// from rng, so the result is deterministic for a given rng.
+func BuildDiamond(h *pals.MDHash, k int, rng *rand.Rand) *Diamond {
+	d := &Diamond{}
+
+	leaves := make([][]byte, 1<<uint(k))
+	seen := make(map[string]bool)
+	for i := range leaves {
+		for {
+			leaves[i] = randomBlock(rng)[:h.Size]
+			if !seen[string(leaves[i])] {
+				seen[string(leaves[i])] = true
+				break
+			}
+		}
+	}
+	d.States = append(d.States, leaves)
+
+	sem := make(chan struct{}, runtime.NumCPU())
+	for level := leaves; len(level) > 1; {
+		next := make([][]byte, len(level)/2)
+		blocks := make([][]byte, len(level))
+
+		var wg sync.WaitGroup
+		for j := 0; j < len(level); j += 2 {
+			wg.Add(1)
+			go func(j int, seed int64) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				x, y, state := findCrossCollision(h, level[j], level[j+1], rand.New(rand.NewSource(seed)))
+				blocks[j], blocks[j+1], next[j/2] = x, y, state
+			}(j, rng.Int63())
+		}
+		wg.Wait()
+
+		d.Blocks = append(d.Blocks, blocks)
+		d.States = append(d.States, next)
+		level = next
+	}
+	return d
+}
+
+// K returns the number of levels of the diamond
+func (d *Diamond) K() int {
+	return len(d.Blocks)
+}
+
+// Commit returns the commitment for predictions with the given number of
+// prefix blocks: prefix, a glue block and k diamond blocks lead to the root,
+// followed by the padding for that length.
+func (d *Diamond) Commit(h *pals.MDHash, prefixBlocks int) Commitment {
+	root := d.States[len(d.States)-1][0]
+	length := (prefixBlocks + 1 + d.K()) * pals.MDBlockSize
+	return Commitment{Digest: h.Iterate(root, pals.MDPadding(length)), PrefixBlocks: prefixBlocks}
+}
+
+// Predict herds the given prefix, which must consist of as many full blocks
+// as the commitment was made for, into the diamond. It finds a glue block
+// from the state after the prefix to one of the leaves and follows the
+// diamond to the root.
+func (d *Diamond) Predict(h *pals.MDHash, c Commitment, prefix []byte, rng *rand.Rand) ([]byte, error) {
+	if len(prefix) != c.PrefixBlocks*pals.MDBlockSize {
+		return nil, fmt.Errorf("prefix must consist of %v full blocks, got %v bytes", c.PrefixBlocks, len(prefix))
+	}
+	leaves := make(map[string]int)
+	for i, s := range d.States[0] {
+		leaves[string(s)] = i
+	}
+
+	state := h.Iterate(h.IV, prefix)
+	for tries := 0; tries < 1<<uint(8*h.Size+2); tries++ {
+		glue := randomBlock(rng)
+		leaf, ok := leaves[string(h.Compress(state, glue))]
+		if !ok {
+			continue
+		}
+
+		out := append(append([]byte{}, prefix...), glue...)
+		for level, j := 0, leaf; level < d.K(); level, j = level+1, j/2 {
+			out = append(out, d.Blocks[level][j]...)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("no glue block found")
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+func TestHerding(t *testing.T) {
+	ex := []struct {
+		bits     int
+		k        int
+		prefixes []string
+	}{
+		{16, 4, []string{"Cubs 4, Sox 3", "Mets 2, Yankees 9"}},
+		{16, 6, []string{"Giants 7, Dodgers 5", "Giants 5, Dodgers 7"}},
+		{24, 6, []string{"Cubs 4, Sox 3"}},
+	}
+
+	for _, e := range ex {
+		const prefixBlocks = 2
+		h, err := pals.NewMDHash(e.bits)
+		if err != nil {
+			t.Fatalf("failed to create hash: %v", err)
+		}
+		d := BuildDiamond(h, e.k, rand.New(rand.NewSource(1)))
+		if d.K() != e.k || len(d.States[0]) != 1<<uint(e.k) {
+			t.Fatalf("Diamond has wrong shape: Expected: k=%v Got: k=%v with %v leaves", e.k, d.K(), len(d.States[0]))
+		}
+		commitment := d.Commit(h, prefixBlocks)
+
+		rng := rand.New(rand.NewSource(2))
+		for _, p := range e.prefixes {
+			prefix := padPrediction(p, prefixBlocks)
+			prediction, err := d.Predict(h, commitment, prefix, rng)
+			if err != nil {
+				t.Fatalf("herding %q failed: %v", p, err)
+			}
+			if !bytes.HasPrefix(prediction, prefix) {
+				t.Errorf("Prediction %q doesn't start with %q", prediction, prefix)
+			}
+			if got := h.Sum(prediction); !bytes.Equal(got, commitment.Digest) {
+				t.Errorf("Herding %q (bits: %v, k: %v) failed: \nExp: %x \nGot: %x", p, e.bits, e.k, commitment.Digest, got)
+			}
+		}
+	}
+}
+
+func TestPredictPrefixLength(t *testing.T) {
+	h, err := pals.NewMDHash(16)
+	if err != nil {
+		t.Fatalf("failed to create hash: %v", err)
+	}
+	d := BuildDiamond(h, 4, rand.New(rand.NewSource(1)))
+	rng := rand.New(rand.NewSource(2))
+	c := d.Commit(h, 2)
+	for _, blocks := range []int{1, 3} {
+		if _, err := d.Predict(h, c, padPrediction("Cubs 4, Sox 3", blocks), rng); err == nil {
+			t.Errorf("Predict accepted a %v block prefix after committing to 2", blocks)
+		}
+	}
+}
+
+func TestBuildDiamondDeterministic(t *testing.T) {
+	h, err := pals.NewMDHash(16)
+	if err != nil {
+		t.Fatalf("failed to create hash: %v", err)
+	}
+	a := BuildDiamond(h, 5, rand.New(rand.NewSource(7)))
+	b := BuildDiamond(h, 5, rand.New(rand.NewSource(7)))
+	if !bytes.Equal(a.Commit(h, 1).Digest, b.Commit(h, 1).Digest) {
+		t.Errorf("Diamonds built from the same seed differ: %x != %x", a.Commit(h, 1).Digest, b.Commit(h, 1).Digest)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+const c56Cookie = "QkUgU1VSRSBUTyBEUklOSyBZT1VSIE9WQUxUSU5F"
+
+// rc4Biases are single byte biases of the RC4 keystream: the byte at
+// index Pos is more likely than others to be Value
+var rc4Biases = []struct {
+	Pos   int
+	Value byte
+}{
+	{15, 0xf0}, // Z16 is biased towards 240
+	{31, 0xe0}, // Z32 is biased towards 224
+}
+
+// RC4Oracle encrypts attacker supplied requests followed by a secret
+// cookie under RC4, with a fresh random key for every request
+type RC4Oracle struct {
+	cookie []byte
+
+	// keys, if set, is used to draw the keys instead of crypto/rand, to
+	// make runs reproducible
+	mu   sync.Mutex
+	keys *rand.Rand
+}
+
+// Encrypt returns RC4(request || cookie) under a fresh random key
+func (o *RC4Oracle) Encrypt(request []byte) []byte {
+	key, err := o.newKey()
+	if err != nil {
+		log.Fatalf("failed to generate key: %v", err)
+	}
+	c, err := pals.NewRC4(key)
+	if err != nil {
+		log.Fatalf("failed to create RC4: %v", err)
+	}
+	plain := append(append([]byte{}, request...), o.cookie...)
+	c.XORKeyStream(plain, plain)
+	return plain
+}
+
+func (o *RC4Oracle) newKey() ([]byte, error) {
+	if o.keys == nil {
+		return pals.GenerateRandomBytes(keySize)
+	}
+	key := make([]byte, keySize)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, err := o.keys.Read(key)
+	return key, err
+}
+
+// C56 solution
+func C56() {
+	fmt.Println("---------------------- c56 ------------------------")
+	cookie, err := base64.StdEncoding.DecodeString(c56Cookie)
+	if err != nil {
+		log.Fatalf("failed to decode cookie: %v", err)
+	}
+	oracle := &RC4Oracle{cookie: cookie}
+
+	recovered, err := RC4BiasAttack(oracle, 1<<24, func(i int, b byte) {
+		fmt.Printf("Recovered byte %v: %q\n", i, b)
+	})
+	if err != nil {
+		log.Fatalf("attack failed: %v", err)
+	}
+	fmt.Printf("Recovered cookie %q (correct? %v)\n", recovered, bytes.Equal(recovered, cookie))
+}
+
+// RC4BiasAttack recovers the oracle's cookie byte by byte using the biases
+// of the RC4 keystream, taking the given number of samples per byte.
+// If progress is non-nil, it is called after each recovered byte.
+func RC4BiasAttack(oracle *RC4Oracle, samples int, progress func(i int, b byte)) ([]byte, error) {
+	n := len(oracle.Encrypt(nil))
+	last := rc4Biases[len(rc4Biases)-1].Pos
+	if n > last+1 {
+		return nil, fmt.Errorf("cookie of %v bytes too long, at most %v supported", n, last+1)
+	}
+
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = RecoverCookieByte(oracle, i, samples)
+		if progress != nil {
+			progress(i, out[i])
+		}
+	}
+	return out, nil
+}
+
+// RecoverCookieByte recovers byte i of the cookie.
+//
+// A prefix of the right length moves the cookie byte to a biased keystream
+// position. Over many encryptions under fresh keys the most frequent
+// cyphertext byte at that position is the cookie byte XOR the bias value.
+// The encryptions are spread over all CPU cores, each counting into its
+// own histogram.
+func RecoverCookieByte(oracle *RC4Oracle, i, samples int) byte {
+	bias := rc4Biases[len(rc4Biases)-1]
+	for _, b := range rc4Biases {
+		if b.Pos >= i {
+			bias = b
+			break
+		}
+	}
+	prefix := bytes.Repeat([]byte("A"), bias.Pos-i)
+
+	workers := runtime.NumCPU()
+	counts := make([][256]int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for s := w; s < samples; s += workers {
+				counts[w][oracle.Encrypt(prefix)[bias.Pos]]++
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	var total [256]int
+	for w := range counts {
+		for c := range total {
+			total[c] += counts[w][c]
+		}
+	}
+	best := 0
+	for c := range total {
+		if total[c] > total[best] {
+			best = c
+		}
+	}
+	return byte(best) ^ bias.Value
+}
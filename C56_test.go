@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestRC4Oracle(t *testing.T) {
+	oracle := &RC4Oracle{cookie: []byte("BE SURE TO DRINK YOUR OVALTINE")}
+	a := oracle.Encrypt([]byte("AAAA"))
+	b := oracle.Encrypt([]byte("AAAA"))
+	if len(a) != 4+len(oracle.cookie) {
+		t.Errorf("Oracle output has wrong length: Expected: %v Got: %v", 4+len(oracle.cookie), len(a))
+	}
+	if bytes.Equal(a, b) {
+		t.Errorf("Oracle reuses keys")
+	}
+}
+
+func TestRecoverCookieByte(t *testing.T) {
+	if testing.Short() {
+		t.Skip("needs 2^22 RC4 encryptions")
+	}
+	cookie := []byte("BE SURE TO DRINK YOUR OVALTINE")
+	// Seeding the keys makes the counts, and so the result, deterministic
+	oracle := &RC4Oracle{cookie: cookie, keys: rand.New(rand.NewSource(56))}
+
+	// Z16 is biased strongly enough that 2^22 samples pin this byte down
+	const i = 10
+	if got := RecoverCookieByte(oracle, i, 1<<22); got != cookie[i] {
+		t.Errorf("Recovering byte %v failed: Expected: %q Got: %q", i, cookie[i], got)
+	}
+}
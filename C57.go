@@ -0,0 +1,156 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dh"
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// DHBob is a Diffie-Hellman peer that answers every public key it is sent
+// with a message, MACed under the resulting shared secret
+type DHBob struct {
+	priv *dh.PrivateKey
+	msg  []byte
+}
+
+// NewDHBob creates a Bob with a fresh key in the given group
+func NewDHBob(group dh.Group) (*DHBob, error) {
+	priv, err := dh.GenerateKey(group)
+	if err != nil {
+		return nil, err
+	}
+	return &DHBob{priv: priv, msg: []byte("crazy flamboyant for the rap enjoyment")}, nil
+}
+
+// PublicKey returns Bob's public key
+func (b *DHBob) PublicKey() *big.Int {
+	return b.priv.Y
+}
+
+// Respond computes the shared secret for the peer's public key h and
+// returns Bob's message with its HMAC-SHA256 tag under that secret
+func (b *DHBob) Respond(h *big.Int) (msg, tag []byte) {
+	return b.msg, dhMAC(b.priv.SharedSecret(h), b.msg)
+}
+
+// dhMAC tags msg with HMAC-SHA256 keyed by the shared secret
+func dhMAC(secret *big.Int, msg []byte) []byte {
+	mac := hmac.New(sha256.New, secret.Bytes())
+	mac.Write(msg)
+	return mac.Sum(nil)
+}
+
+// C57 solution
+func C57() {
+	fmt.Println("---------------------- c57 ------------------------")
+	bob, err := NewDHBob(dh.Set8Group)
+	if err != nil {
+		log.Fatalf("failed to create Bob: %v", err)
+	}
+
+	x, err := SubgroupConfinementAttack(dh.Set8Group, bob)
+	if err != nil {
+		log.Fatalf("attack failed: %v", err)
+	}
+	fmt.Printf("Recovered Bob's private key %v (correct? %v)\n", x, x.Cmp(bob.priv.X) == 0)
+}
+
+// SmallFactors returns the distinct prime factors of n below bound that
+// divide it exactly once
+func SmallFactors(n *big.Int, bound int64) []*big.Int {
+	var out []*big.Int
+	rem := new(big.Int).Set(n)
+	m := new(big.Int)
+	for r := int64(2); r < bound; r++ {
+		br := big.NewInt(r)
+		count := 0
+		for m.Mod(rem, br).Sign() == 0 {
+			rem.Quo(rem, br)
+			count++
+		}
+		if count == 1 {
+			out = append(out, br)
+		}
+	}
+	return out
+}
+
+// ElementOfOrder returns a random element of order r in the multiplicative
+// group mod p, where r is a prime dividing p-1
+func ElementOfOrder(p, r *big.Int) (*big.Int, error) {
+	exp := new(big.Int).Sub(p, big.NewInt(1))
+	exp.Quo(exp, r)
+	for {
+		rnd, err := rand.Int(rand.Reader, p)
+		if err != nil {
+			return nil, err
+		}
+		h := new(big.Int).Exp(rnd, exp, p)
+		if h.Cmp(big.NewInt(1)) != 0 {
+			return h, nil
+		}
+	}
+}
+
+// SubgroupResidues recovers x mod r for the small factors r of the group's
+// cofactor, until their product covers at least minProduct.
+// Returns the residues and their moduli.
+func SubgroupResidues(group dh.Group, bob *DHBob, minProduct *big.Int) (residues, moduli []*big.Int, err error) {
+	product := big.NewInt(1)
+	for _, r := range SmallFactors(group.Cofactor(), 1<<16) {
+		if product.Cmp(minProduct) >= 0 {
+			break
+		}
+		h, err := ElementOfOrder(group.P, r)
+		if err != nil {
+			return nil, nil, err
+		}
+		msg, tag := bob.Respond(h)
+
+		// The shared secret h^x only takes r values, so try them all
+		b, found := int64(0), false
+		k := big.NewInt(1)
+		for ; b < r.Int64(); b++ {
+			if hmac.Equal(dhMAC(k, msg), tag) {
+				found = true
+				break
+			}
+			k.Mul(k, h)
+			k.Mod(k, group.P)
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("no residue mod %v matches Bob's MAC", r)
+		}
+
+		residues = append(residues, big.NewInt(b))
+		moduli = append(moduli, r)
+		product.Mul(product, r)
+	}
+	return residues, moduli, nil
+}
+
+// SubgroupConfinementAttack recovers Bob's private key.
+//
+// Sending Bob an element h of small prime order r confines the shared
+// secret h^x to r possible values, so brute forcing Bob's MAC reveals
+// x mod r. Once the product of the r exceeds q, the CRT gives x.
+func SubgroupConfinementAttack(group dh.Group, bob *DHBob) (*big.Int, error) {
+	residues, moduli, err := SubgroupResidues(group, bob, group.Q)
+	if err != nil {
+		return nil, err
+	}
+	x, m, err := rsa.CRT(residues, moduli)
+	if err != nil {
+		return nil, err
+	}
+	if m.Cmp(group.Q) < 0 {
+		return nil, fmt.Errorf("small factors only cover %v bits of q", m.BitLen())
+	}
+	return x, nil
+}
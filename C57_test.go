@@ -0,0 +1,48 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dh"
+)
+
+func TestSmallFactors(t *testing.T) {
+	ex := []struct {
+		n        int64
+		bound    int64
+		expected []int64
+	}{
+		{2 * 9 * 5 * 109, 1000, []int64{2, 5, 109}},
+		{2 * 9 * 5 * 109, 100, []int64{2, 5}},
+		{1009 * 1013, 1000, nil},
+	}
+	for _, e := range ex {
+		result := SmallFactors(big.NewInt(e.n), e.bound)
+		if len(result) != len(e.expected) {
+			t.Errorf("SmallFactors(%v) failed: Expected: %v Got: %v", e.n, e.expected, result)
+			continue
+		}
+		for i := range result {
+			if result[i].Int64() != e.expected[i] {
+				t.Errorf("SmallFactors(%v) failed: Expected: %v Got: %v", e.n, e.expected, result)
+			}
+		}
+	}
+}
+
+func TestSubgroupConfinementAttack(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		bob, err := NewDHBob(dh.Set8Group)
+		if err != nil {
+			t.Fatalf("failed to create Bob: %v", err)
+		}
+		x, err := SubgroupConfinementAttack(dh.Set8Group, bob)
+		if err != nil {
+			t.Fatalf("attack failed: %v", err)
+		}
+		if x.Cmp(bob.priv.X) != 0 {
+			t.Errorf("Subgroup confinement attack failed: \nExp: %v \nGot: %v", bob.priv.X, x)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dh"
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// C58 solution
+func C58() {
+	fmt.Println("---------------------- c58 ------------------------")
+	bob, err := NewDHBob(dh.KangarooGroup)
+	if err != nil {
+		log.Fatalf("failed to create Bob: %v", err)
+	}
+
+	x, err := KangarooAttack(dh.KangarooGroup, bob)
+	if err != nil {
+		log.Fatalf("attack failed: %v", err)
+	}
+	fmt.Printf("Recovered Bob's private key %v (correct? %v)\n", x, x.Cmp(bob.priv.X) == 0)
+}
+
+// KangarooAttack recovers Bob's private key in a group where the small
+// factors of the cofactor don't cover all of q.
+//
+// The subgroup confinement attack gives x = n mod r. Writing x = n + m*r,
+// Bob's public key y satisfies y * g^-n = (g^r)^m with m in [0, (q-1)/r],
+// which the kangaroo method solves in about sqrt(q/r) steps.
+func KangarooAttack(group dh.Group, bob *DHBob) (*big.Int, error) {
+	residues, moduli, err := SubgroupResidues(group, bob, group.Q)
+	if err != nil {
+		return nil, err
+	}
+	n, r, err := rsa.CRT(residues, moduli)
+	if err != nil {
+		return nil, err
+	}
+	if r.Cmp(group.Q) >= 0 {
+		return n, nil
+	}
+
+	gr := new(big.Int).Exp(group.G, r, group.P)
+	gn := new(big.Int).Exp(group.G, n, group.P)
+	gnInv := new(big.Int).ModInverse(gn, group.P)
+	yr := new(big.Int).Mul(bob.PublicKey(), gnInv)
+	yr.Mod(yr, group.P)
+
+	upper := new(big.Int).Sub(group.Q, big.NewInt(1))
+	upper.Quo(upper, r)
+	m, err := dh.Kangaroo(gr, yr, group.P, new(big.Int), upper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find x mod q in %v bit interval: %v", upper.BitLen(), err)
+	}
+	x := new(big.Int).Mul(m, r)
+	return x.Add(x, n), nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dh"
+)
+
+func TestKangarooAttack(t *testing.T) {
+	bob, err := NewDHBob(dh.KangarooGroup)
+	if err != nil {
+		t.Fatalf("failed to create Bob: %v", err)
+	}
+	x, err := KangarooAttack(dh.KangarooGroup, bob)
+	if err != nil {
+		t.Fatalf("attack failed: %v", err)
+	}
+	if x.Cmp(bob.priv.X) != 0 {
+		t.Errorf("Kangaroo attack failed: \nExp: %v \nGot: %v", bob.priv.X, x)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/ec"
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// InvalidCurve is a curve sharing p and a with the target curve, along
+// with the order of its group
+type InvalidCurve struct {
+	B     *big.Int
+	Order *big.Int
+}
+
+// c59Curves are curves with a different b whose orders have many small
+// factors
+var c59Curves = []InvalidCurve{
+	{big.NewInt(210), ec.FromDecimal("233970423115425145550826547352470124412")},
+	{big.NewInt(504), ec.FromDecimal("233970423115425145544350131142039591210")},
+	{big.NewInt(727), ec.FromDecimal("233970423115425145545378039958152057148")},
+}
+
+// ECDHBob is an ECDH peer that answers every public point it is sent with
+// a message, MACed under the resulting shared point
+type ECDHBob struct {
+	priv *ec.PrivateKey
+	msg  []byte
+}
+
+// NewECDHBob creates a Bob with a fresh key on the given curve
+func NewECDHBob(curve ec.Curve) (*ECDHBob, error) {
+	priv, err := ec.GenerateKey(curve)
+	if err != nil {
+		return nil, err
+	}
+	return &ECDHBob{priv: priv, msg: []byte("crazy flamboyant for the rap enjoyment")}, nil
+}
+
+// PublicKey returns Bob's public point
+func (b *ECDHBob) PublicKey() ec.Point {
+	return b.priv.Public
+}
+
+// Respond computes the shared point for the peer's public point h, which
+// is not validated, and returns Bob's message with its tag under that point
+func (b *ECDHBob) Respond(h ec.Point) (msg, tag []byte) {
+	return b.msg, ecdhMAC(b.priv.SharedSecret(h), b.msg)
+}
+
+// ecdhMAC tags msg with HMAC-SHA256 keyed by the coordinates of the
+// shared point
+func ecdhMAC(secret ec.Point, msg []byte) []byte {
+	var key []byte
+	if !secret.IsInfinity() {
+		key = append(secret.X.Bytes(), secret.Y.Bytes()...)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+	return mac.Sum(nil)
+}
+
+// C59 solution
+func C59() {
+	fmt.Println("---------------------- c59 ------------------------")
+	bob, err := NewECDHBob(ec.Set8Curve)
+	if err != nil {
+		log.Fatalf("failed to create Bob: %v", err)
+	}
+
+	d, err := InvalidCurveAttack(ec.Set8Curve, bob, c59Curves)
+	if err != nil {
+		log.Fatalf("attack failed: %v", err)
+	}
+	fmt.Printf("Recovered Bob's private key %v (correct? %v)\n", d, d.Cmp(bob.priv.D) == 0)
+}
+
+// InvalidCurveAttack recovers Bob's private key.
+//
+// Addition never uses b, so Bob happily multiplies points from a curve with
+// a different b by his key. Sending a point h of small prime order r on such
+// a curve confines the shared point to r values, and brute forcing Bob's MAC
+// reveals d mod r. Once the product of the r exceeds N, the CRT gives d.
+func InvalidCurveAttack(curve ec.Curve, bob *ECDHBob, curves []InvalidCurve) (*big.Int, error) {
+	var residues, moduli []*big.Int
+	product := big.NewInt(1)
+	seen := make(map[int64]bool)
+	for _, ic := range curves {
+		invalid := curve.WithB(ic.B)
+		for _, r := range SmallFactors(ic.Order, 1<<16) {
+			if product.Cmp(curve.N) >= 0 {
+				break
+			}
+			if seen[r.Int64()] {
+				continue
+			}
+			h, err := invalid.PointOfOrder(rand.Reader, ic.Order, r)
+			if err != nil {
+				return nil, err
+			}
+			msg, tag := bob.Respond(h)
+
+			// The shared point is one of k*h for k in [0, r)
+			b, found := int64(0), false
+			k := ec.Infinity
+			for ; b < r.Int64(); b++ {
+				if hmac.Equal(ecdhMAC(k, msg), tag) {
+					found = true
+					break
+				}
+				k = invalid.Add(k, h)
+			}
+			if !found {
+				return nil, fmt.Errorf("no residue mod %v matches Bob's MAC", r)
+			}
+
+			residues = append(residues, big.NewInt(b))
+			moduli = append(moduli, r)
+			product.Mul(product, r)
+			seen[r.Int64()] = true
+		}
+	}
+
+	d, m, err := rsa.CRT(residues, moduli)
+	if err != nil {
+		return nil, err
+	}
+	if m.Cmp(curve.N) < 0 {
+		return nil, fmt.Errorf("small factors only cover %v bits of N", m.BitLen())
+	}
+	return d, nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/ec"
+)
+
+func TestInvalidCurveAttack(t *testing.T) {
+	bob, err := NewECDHBob(ec.Set8Curve)
+	if err != nil {
+		t.Fatalf("failed to create Bob: %v", err)
+	}
+	d, err := InvalidCurveAttack(ec.Set8Curve, bob, c59Curves)
+	if err != nil {
+		t.Fatalf("attack failed: %v", err)
+	}
+	if d.Cmp(bob.priv.D) != 0 {
+		t.Errorf("Invalid curve attack failed: \nExp: %v \nGot: %v", bob.priv.D, d)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/ec"
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// c60TwistOrder is the order of the quadratic twist of ec.Set8Montgomery,
+// 2p + 2 - 8N
+var c60TwistOrder = ec.FromDecimal("233970423115425145549737651362517029924")
+
+// c60Bound limits the twist subgroups used. The twist order's next factor
+// after 2323367 is around 2^40, which is better left to the kangaroo.
+const c60Bound = 1 << 22
+
+// MontgomeryBob is an x-only ECDH peer: he only ever sends and receives u
+// coordinates, and answers every u he is sent with a message, MACed under
+// the u coordinate of the shared point
+type MontgomeryBob struct {
+	curve ec.MontgomeryCurve
+	d     *big.Int
+	msg   []byte
+}
+
+// NewMontgomeryBob creates a Bob with a fresh key on the given curve
+func NewMontgomeryBob(curve ec.MontgomeryCurve) (*MontgomeryBob, error) {
+	d, err := rand.Int(rand.Reader, new(big.Int).Sub(curve.N, big.NewInt(1)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret scalar: %v", err)
+	}
+	return &MontgomeryBob{
+		curve: curve,
+		d:     d.Add(d, big.NewInt(1)),
+		msg:   []byte("crazy flamboyant for the rap enjoyment"),
+	}, nil
+}
+
+// PublicKey returns the u coordinate of Bob's public point
+func (b *MontgomeryBob) PublicKey() *big.Int {
+	return b.curve.Ladder(b.curve.G.X, b.d)
+}
+
+// Respond computes the shared u coordinate for the peer's u, which is not
+// validated, and returns Bob's message with its tag under the shared u
+func (b *MontgomeryBob) Respond(u *big.Int) (msg, tag []byte) {
+	return b.msg, ladderMAC(b.curve.Ladder(u, b.d), b.msg)
+}
+
+// ladderMAC tags msg with HMAC-SHA256 keyed by the shared u coordinate
+func ladderMAC(u *big.Int, msg []byte) []byte {
+	mac := hmac.New(sha256.New, u.Bytes())
+	mac.Write(msg)
+	return mac.Sum(nil)
+}
+
+// C60 solution
+func C60() {
+	fmt.Println("---------------------- c60 ------------------------")
+	bob, err := NewMontgomeryBob(ec.Set8Montgomery)
+	if err != nil {
+		log.Fatalf("failed to create Bob: %v", err)
+	}
+
+	d, err := TwistAttack(ec.Set8Montgomery, bob, c60TwistOrder, c60Bound)
+	if err != nil {
+		log.Fatalf("attack failed: %v", err)
+	}
+	negD := new(big.Int).Sub(bob.curve.N, d)
+	fmt.Printf("Recovered Bob's private key ±%v (correct? %v)\n", d, d.Cmp(bob.d) == 0 || negD.Cmp(bob.d) == 0)
+}
+
+// TwistAttack recovers Bob's private key up to sign.
+//
+// The ladder never looks at v, so Bob also accepts u coordinates of points
+// on the curve's quadratic twist, whose order has small factors r below
+// bound. As with the invalid curve attack, sending a point of order r
+// reveals d mod r, but only up to sign, since u(kP) = u(-kP). Querying a
+// point of order R*r tells which of the two ways to combine the new residue
+// with the previous ones is right, leaving d = ±n mod R.
+// The kangaroo then finds the rest of d, trying both signs at once.
+func TwistAttack(curve ec.MontgomeryCurve, bob *MontgomeryBob, twistOrder *big.Int, bound int64) (*big.Int, error) {
+	n, modulus := new(big.Int), big.NewInt(1)
+	var moduli []*big.Int
+	for _, r := range SmallFactors(twistOrder, bound) {
+		if r.Cmp(big.NewInt(2)) == 0 {
+			// (0, 0) is the same point on the curve and the twist
+			continue
+		}
+		b, err := twistResidue(curve, bob, twistOrder, r)
+		if err != nil {
+			return nil, err
+		}
+		if len(moduli) == 0 {
+			n.Set(b)
+		} else {
+			n, err = combineResidues(curve, bob, twistOrder, append(moduli, r), n, b)
+			if err != nil {
+				return nil, err
+			}
+		}
+		moduli = append(moduli, r)
+		modulus.Mul(modulus, r)
+	}
+
+	// Lift Bob's public key to a point Q = ±d*G
+	w := curve.Weierstrass()
+	lifted, err := curve.Lift(bob.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	q := curve.ToWeierstrass(lifted)
+
+	// One of D = ±d has D = n mod R, and D*G = ±Q. With D = n + m*R,
+	// ±Q - n*G = m*(R*G) for m in [-N/R, N/R]
+	gr := w.ScalarMult(w.G, modulus)
+	upper := new(big.Int).Quo(w.N, modulus)
+	lower := new(big.Int).Neg(upper)
+	nG := w.Neg(w.ScalarBaseMult(n))
+	ys := []ec.Point{w.Add(q, nG), w.Add(w.Neg(q), nG)}
+	m, _, err := w.KangarooAny(gr, ys, lower, upper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find d mod N in %v bit interval: %v", upper.BitLen()+1, err)
+	}
+	d := new(big.Int).Mul(m, modulus)
+	d.Add(d, n)
+	return d.Mod(d, w.N), nil
+}
+
+// twistResidue finds b such that d = ±b mod r, for a prime r dividing the
+// twist order
+func twistResidue(curve ec.MontgomeryCurve, bob *MontgomeryBob, twistOrder, r *big.Int) (*big.Int, error) {
+	h, err := twistPointOfOrder(curve, twistOrder, []*big.Int{r})
+	if err != nil {
+		return nil, err
+	}
+	msg, tag := bob.Respond(h)
+
+	// Walk u(kh) for k in [0, r/2] with differential additions, since
+	// u((k+1)h) only depends on u(kh), u(h) and u((k-1)h)
+	if hmac.Equal(ladderMAC(new(big.Int), msg), tag) {
+		return new(big.Int), nil
+	}
+	prev, cur := h, h
+	half := r.Int64() / 2
+	for k := int64(1); k <= half; k++ {
+		if hmac.Equal(ladderMAC(cur, msg), tag) {
+			return big.NewInt(k), nil
+		}
+		if k == 1 {
+			cur = curve.XDouble(h)
+		} else {
+			prev, cur = cur, curve.XAdd(cur, h, prev)
+		}
+		if cur == nil {
+			break
+		}
+	}
+	return nil, fmt.Errorf("no residue mod %v matches Bob's MAC", r)
+}
+
+// combineResidues picks the combination of d = ±n mod R and d = ±b mod r,
+// where moduli are the prime factors of R*r, that agrees with Bob on a point
+// of order R*r. The result is d mod R*r, up to sign.
+func combineResidues(curve ec.MontgomeryCurve, bob *MontgomeryBob, twistOrder *big.Int, moduli []*big.Int, n, b *big.Int) (*big.Int, error) {
+	prev := new(big.Int).Set(moduli[0])
+	for _, r := range moduli[1 : len(moduli)-1] {
+		prev.Mul(prev, r)
+	}
+	r := moduli[len(moduli)-1]
+
+	h, err := twistPointOfOrder(curve, twistOrder, moduli)
+	if err != nil {
+		return nil, err
+	}
+	msg, tag := bob.Respond(h)
+	for _, c := range []*big.Int{b, new(big.Int).Sub(r, b)} {
+		x, _, err := rsa.CRT([]*big.Int{n, c}, []*big.Int{prev, r})
+		if err != nil {
+			return nil, err
+		}
+		if hmac.Equal(ladderMAC(curve.Ladder(h, x), msg), tag) {
+			return x, nil
+		}
+	}
+	return nil, fmt.Errorf("no combination of residues mod %v and %v matches Bob's MAC", prev, r)
+}
+
+// twistPointOfOrder returns the u coordinate of a random point on the twist
+// whose order is the product of the given distinct primes
+func twistPointOfOrder(curve ec.MontgomeryCurve, twistOrder *big.Int, primes []*big.Int) (*big.Int, error) {
+	order := big.NewInt(1)
+	for _, r := range primes {
+		order.Mul(order, r)
+	}
+	cofactor, m := new(big.Int).QuoRem(twistOrder, order, new(big.Int))
+	if m.Sign() != 0 {
+		return nil, fmt.Errorf("%v does not divide the twist order", order)
+	}
+
+next:
+	for {
+		u, err := curve.RandomTwistPoint(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		h := curve.Ladder(u, cofactor)
+		for _, r := range primes {
+			if curve.Ladder(h, new(big.Int).Quo(order, r)).Sign() == 0 {
+				continue next
+			}
+		}
+		return h, nil
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/ec"
+)
+
+// A 48 bit Montgomery curve whose twist order 4*13*521*4951*5347*q has
+// small factors covering all but about 9 bits of N, so the attack is quick
+var c60TestCurve = ec.MontgomeryCurve{
+	P: big.NewInt(207272659594291),
+	A: big.NewInt(7899943547116),
+	B: big.NewInt(1),
+	G: ec.NewPoint(29687918541373, 18092177787632),
+	N: big.NewInt(51818164888337),
+}
+
+var c60TestTwistOrder = big.NewInt(207272659635236)
+
+func TestC60TestCurve(t *testing.T) {
+	m := c60TestCurve
+	if !m.P.ProbablyPrime(20) || !m.N.ProbablyPrime(20) {
+		t.Fatalf("p and N must be prime")
+	}
+	if !m.IsOnCurve(m.G.X) {
+		t.Fatalf("base point is not on the curve")
+	}
+	if m.Ladder(m.G.X, m.N).Sign() != 0 {
+		t.Errorf("base point does not have order N")
+	}
+	// #E + #E' = 2p + 2, with #E = 4N
+	sum := new(big.Int).Lsh(m.N, 2)
+	sum.Add(sum, c60TestTwistOrder)
+	if sum.Cmp(new(big.Int).Add(new(big.Int).Lsh(m.P, 1), big.NewInt(2))) != 0 {
+		t.Errorf("twist order does not match the curve order")
+	}
+}
+
+func TestTwistAttack(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping twist attack in short mode")
+	}
+	bob, err := NewMontgomeryBob(ec.Set8Montgomery)
+	if err != nil {
+		t.Fatalf("failed to create Bob: %v", err)
+	}
+	d, err := TwistAttack(ec.Set8Montgomery, bob, c60TwistOrder, c60Bound)
+	if err != nil {
+		t.Fatalf("attack failed: %v", err)
+	}
+	negD := new(big.Int).Sub(bob.curve.N, d)
+	if d.Cmp(bob.d) != 0 && negD.Cmp(bob.d) != 0 {
+		t.Errorf("Twist attack failed: \nExp: ±%v \nGot: ±%v", bob.d, d)
+	}
+}
+
+func TestTwistAttackTestCurve(t *testing.T) {
+	bob, err := NewMontgomeryBob(c60TestCurve)
+	if err != nil {
+		t.Fatalf("failed to create Bob: %v", err)
+	}
+	d, err := TwistAttack(c60TestCurve, bob, c60TestTwistOrder, 1<<16)
+	if err != nil {
+		t.Fatalf("attack failed: %v", err)
+	}
+	negD := new(big.Int).Sub(bob.curve.N, d)
+	if d.Cmp(bob.d) != 0 && negD.Cmp(bob.d) != 0 {
+		t.Errorf("Twist attack failed: \nExp: ±%v \nGot: ±%v", bob.d, d)
+	}
+}
+
+func TestTwistResidue(t *testing.T) {
+	bob, err := NewMontgomeryBob(ec.Set8Montgomery)
+	if err != nil {
+		t.Fatalf("failed to create Bob: %v", err)
+	}
+	for _, r := range []int64{11, 107, 197, 1621} {
+		b, err := twistResidue(ec.Set8Montgomery, bob, c60TwistOrder, big.NewInt(r))
+		if err != nil {
+			t.Fatalf("twistResidue(%v) failed: %v", r, err)
+		}
+		d := new(big.Int).Mod(bob.d, big.NewInt(r)).Int64()
+		if b.Int64() != d && b.Int64() != r-d {
+			t.Errorf("twistResidue(%v) failed: \nExp: ±%v \nGot: %v", r, d, b)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dh"
+	"github.com/ExalDraen/cryptopals-challenges/pals/ec"
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// C61 solution
+func C61() {
+	fmt.Println("---------------------- c61 ------------------------")
+	const msg = "I, Alice, owe Eve nothing"
+	hashed := sha256.Sum256([]byte(msg))
+
+	// ECDSA
+	alice, err := ec.GenerateKey(ec.Set8Curve)
+	if err != nil {
+		log.Fatalf("failed to generate ECDSA key: %v", err)
+	}
+	r, s, err := ec.Sign(alice, hashed[:])
+	if err != nil {
+		log.Fatalf("failed to sign: %v", err)
+	}
+	eve, err := ECDSADuplicateKey(alice.PublicKey(), hashed[:], r, s)
+	if err != nil {
+		log.Fatalf("failed to find duplicate ECDSA key: %v", err)
+	}
+	fmt.Printf("Alice's ECDSA signature verifies under Eve's key %v: %v\n",
+		eve.Public, ec.Verify(eve.PublicKey(), hashed[:], r, s))
+
+	// RSA
+	rsaAlice, err := rsa.GenerateKey(1024, 65537)
+	if err != nil {
+		log.Fatalf("failed to generate RSA key: %v", err)
+	}
+	sig, err := rsa.SignPKCS1v15(rsaAlice, crypto.SHA256, hashed[:])
+	if err != nil {
+		log.Fatalf("failed to sign: %v", err)
+	}
+	rsaEve, err := RSADuplicateKey(&rsaAlice.PublicKey, crypto.SHA256, hashed[:], sig)
+	if err != nil {
+		log.Fatalf("failed to find duplicate RSA key: %v", err)
+	}
+	fmt.Printf("Alice's RSA signature verifies under Eve's key with a %v bit e: %v\n",
+		rsaEve.E.BitLen(), rsa.VerifyPKCS1v15(&rsaEve.PublicKey, crypto.SHA256, hashed[:], sig) == nil)
+}
+
+// ECDSADuplicateKey creates a new key pair, including a new generator,
+// under which Alice's signature (r, s) of the digest is also valid.
+//
+// Verification checks r against the x coordinate of R = u1*G + u2*Q, which
+// Eve can compute. She picks a random d', then G' = (u1 + u2*d')^-1 * R and
+// Q' = d'*G', so that u1*G' + u2*Q' = R.
+func ECDSADuplicateKey(pub *ec.PublicKey, hashed []byte, r, s *big.Int) (*ec.PrivateKey, error) {
+	u1, u2 := ec.VerifyScalars(pub, hashed, r, s)
+	R := pub.Add(pub.ScalarBaseMult(u1), pub.ScalarMult(pub.Q, u2))
+	if R.IsInfinity() {
+		return nil, fmt.Errorf("signature does not verify")
+	}
+
+	for {
+		d, err := rand.Int(rand.Reader, pub.N)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate secret scalar: %v", err)
+		}
+		t := new(big.Int).Mul(u2, d)
+		t.Add(t, u1)
+		tInv := t.ModInverse(t.Mod(t, pub.N), pub.N)
+		if tInv == nil || d.Sign() == 0 {
+			continue
+		}
+		curve := pub.Curve
+		curve.G = pub.ScalarMult(R, tInv)
+		return ec.NewPrivateKey(curve, d), nil
+	}
+}
+
+// RSADuplicateKey creates a new RSA key pair of the same size under which
+// Alice's PKCS#1 v1.5 signature sig of the digest is also valid.
+//
+// With pad = s^e mod N, Eve needs e' with s^e' = pad mod N'. She picks
+// primes p and q where p-1 and q-1 only have small factors and s generates
+// both groups, so Pohlig-Hellman gives e' mod p-1 and mod q-1. Apart from
+// 2, p-1 and q-1 share no factors, so the CRT combines them.
+func RSADuplicateKey(pub *rsa.PublicKey, hash crypto.Hash, hashed, sig []byte) (*rsa.PrivateKey, error) {
+	if err := rsa.VerifyPKCS1v15(pub, hash, hashed, sig); err != nil {
+		return nil, err
+	}
+	s := rsa.OS2IP(sig)
+	pad := pub.Encrypt(s)
+	bits := pub.N.BitLen()
+	composite := sieve(1 << 17)
+
+	// Eve's modulus needs the same size as Alice's, and to be larger than s
+	top := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	low := new(big.Int).Rsh(top, 1)
+	if s.Cmp(low) > 0 {
+		low.Set(s)
+	}
+	for {
+		used := make(map[int64]bool)
+		pMin := new(big.Int).Lsh(big.NewInt(1), uint(bits/2-1))
+		p, pFactors, err := smoothPrime(pMin, new(big.Int).Lsh(pMin, 1), s, composite, used)
+		if err != nil {
+			return nil, err
+		}
+		qMin := new(big.Int).Quo(low, p)
+		q, qFactors, err := smoothPrime(qMin.Add(qMin, big.NewInt(1)), new(big.Int).Quo(top, p), s, composite, used)
+		if err != nil {
+			return nil, err
+		}
+
+		// As s generates both groups, e' mod 2 tells whether pad is a
+		// square mod p and mod q. e' must be odd to be invertible.
+		if big.Jacobi(pad, p) != -1 || big.Jacobi(pad, q) != -1 {
+			continue
+		}
+
+		ep, err := dh.PohligHellman(new(big.Int).Mod(s, p), new(big.Int).Mod(pad, p), p, pFactors)
+		if err != nil {
+			return nil, err
+		}
+		eq, err := dh.PohligHellman(new(big.Int).Mod(s, q), new(big.Int).Mod(pad, q), q, qFactors)
+		if err != nil {
+			return nil, err
+		}
+
+		// e' = ep mod p-1 and e' = eq mod (q-1)/2, which is odd
+		pm1 := new(big.Int).Sub(p, big.NewInt(1))
+		qm1 := new(big.Int).Sub(q, big.NewInt(1))
+		half := new(big.Int).Rsh(qm1, 1)
+		e, _, err := rsa.CRT([]*big.Int{ep, new(big.Int).Mod(eq, half)}, []*big.Int{pm1, half})
+		if err != nil {
+			return nil, err
+		}
+		d, err := rsa.InvMod(e, new(big.Int).Mul(pm1, half))
+		if err != nil {
+			continue
+		}
+		return &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: e},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}, nil
+	}
+}
+
+// smoothPrime returns a prime p in [min, max) where p-1 is 2 times
+// distinct primes below 2^17 that aren't in used, and g generates the
+// multiplicative group mod p. Returns p and the factors of p-1, which are
+// added to used. composite is a sieve up to 2^17.
+func smoothPrime(min, max, g *big.Int, composite []bool, used map[int64]bool) (*big.Int, []*big.Int, error) {
+	sieveSize := big.NewInt(int64(len(composite)))
+	for {
+		factors := []*big.Int{big.NewInt(2)}
+		pm1 := big.NewInt(2)
+		for new(big.Int).Quo(max, pm1).Cmp(sieveSize) >= 0 {
+			r, err := rand.Int(rand.Reader, big.NewInt(1<<16))
+			if err != nil {
+				return nil, nil, err
+			}
+			if r.Int64() < 3 || composite[r.Int64()] || used[r.Int64()] || containsInt(factors, r) {
+				continue
+			}
+			factors = append(factors, r)
+			pm1.Mul(pm1, r)
+		}
+
+		// Pick the last factor r so that pm1*r + 1 lands in [min, max)
+		lo := new(big.Int).Sub(min, big.NewInt(2))
+		lo.Quo(lo, pm1)
+		lo.Add(lo, big.NewInt(1))
+		if lo.Int64() < 3 {
+			lo.SetInt64(3)
+		}
+		hi := new(big.Int).Sub(max, big.NewInt(2))
+		hi.Quo(hi, pm1)
+		if hi.Cmp(lo) < 0 {
+			continue
+		}
+		span := new(big.Int).Sub(hi, lo)
+		r, err := rand.Int(rand.Reader, span.Add(span, big.NewInt(1)))
+		if err != nil {
+			return nil, nil, err
+		}
+		r.Add(r, lo)
+		if composite[r.Int64()] || used[r.Int64()] || containsInt(factors, r) {
+			continue
+		}
+		factors = append(factors, r)
+		pm1.Mul(pm1, r)
+
+		p := new(big.Int).Add(pm1, big.NewInt(1))
+		if !p.ProbablyPrime(20) || !isGenerator(g, p, factors) {
+			continue
+		}
+		for _, f := range factors[1:] {
+			used[f.Int64()] = true
+		}
+		return p, factors, nil
+	}
+}
+
+// isGenerator reports whether g generates the multiplicative group mod p,
+// given the distinct prime factors of p-1
+func isGenerator(g, p *big.Int, factors []*big.Int) bool {
+	pm1 := new(big.Int).Sub(p, big.NewInt(1))
+	gp := new(big.Int).Mod(g, p)
+	if gp.Sign() == 0 {
+		return false
+	}
+	for _, f := range factors {
+		if new(big.Int).Exp(gp, new(big.Int).Quo(pm1, f), p).Cmp(big.NewInt(1)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sieve returns whether each number below n is composite, using the sieve
+// of Eratosthenes
+func sieve(n int) []bool {
+	composite := make([]bool, n)
+	composite[0], composite[1] = true, true
+	for i := 2; i*i < n; i++ {
+		if composite[i] {
+			continue
+		}
+		for j := i * i; j < n; j += i {
+			composite[j] = true
+		}
+	}
+	return composite
+}
+
+func containsInt(xs []*big.Int, x *big.Int) bool {
+	for _, y := range xs {
+		if y.Cmp(x) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/ec"
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+func TestECDSADuplicateKey(t *testing.T) {
+	hashed := sha256.Sum256([]byte("hi mom"))
+	alice, err := ec.GenerateKey(ec.Set8Curve)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	r, s, err := ec.Sign(alice, hashed[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	eve, err := ECDSADuplicateKey(alice.PublicKey(), hashed[:], r, s)
+	if err != nil {
+		t.Fatalf("failed to find duplicate key: %v", err)
+	}
+	if eve.Public.Equal(alice.Public) {
+		t.Errorf("Duplicate key is Alice's key")
+	}
+	if !ec.Verify(eve.PublicKey(), hashed[:], r, s) {
+		t.Errorf("Alice's signature does not verify under Eve's key")
+	}
+
+	// Eve's key is a proper key pair
+	other := sha256.Sum256([]byte("hi dad"))
+	r, s, err = ec.Sign(eve, other[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if !ec.Verify(eve.PublicKey(), other[:], r, s) {
+		t.Errorf("Eve's own signature does not verify")
+	}
+}
+
+func TestRSADuplicateKey(t *testing.T) {
+	hashed := sha256.Sum256([]byte("hi mom"))
+	alice, err := rsa.GenerateKey(512, 65537)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	sig, err := rsa.SignPKCS1v15(alice, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	eve, err := RSADuplicateKey(&alice.PublicKey, crypto.SHA256, hashed[:], sig)
+	if err != nil {
+		t.Fatalf("failed to find duplicate key: %v", err)
+	}
+	if eve.N.Cmp(alice.N) == 0 {
+		t.Errorf("Duplicate key is Alice's key")
+	}
+	if err := rsa.VerifyPKCS1v15(&eve.PublicKey, crypto.SHA256, hashed[:], sig); err != nil {
+		t.Errorf("Alice's signature does not verify under Eve's key: %v", err)
+	}
+
+	// Eve's key is a proper key pair
+	other := sha256.Sum256([]byte("hi dad"))
+	sig, err = rsa.SignPKCS1v15(eve, crypto.SHA256, other[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if err := rsa.VerifyPKCS1v15(&eve.PublicKey, crypto.SHA256, other[:], sig); err != nil {
+		t.Errorf("Eve's own signature does not verify: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dsa"
+	"github.com/ExalDraen/cryptopals-challenges/pals/ec"
+	"github.com/ExalDraen/cryptopals-challenges/pals/lattice"
+)
+
+const (
+	c62ZeroBits   = 8
+	c62Signatures = 22
+)
+
+// ECDSASignature is a signature (r, s) together with the signed digest
+type ECDSASignature struct {
+	Hashed []byte
+	R, S   *big.Int
+}
+
+// BiasedSigner signs with ECDSA, using nonces whose low bits are zero
+type BiasedSigner struct {
+	priv     *ec.PrivateKey
+	zeroBits uint
+}
+
+// NewBiasedSigner creates a signer with a fresh key on the given curve
+func NewBiasedSigner(curve ec.Curve, zeroBits uint) (*BiasedSigner, error) {
+	priv, err := ec.GenerateKey(curve)
+	if err != nil {
+		return nil, err
+	}
+	return &BiasedSigner{priv: priv, zeroBits: zeroBits}, nil
+}
+
+// PublicKey returns the signer's public key
+func (b *BiasedSigner) PublicKey() *ec.PublicKey {
+	return b.priv.PublicKey()
+}
+
+// Sign signs the SHA-256 digest of msg
+func (b *BiasedSigner) Sign(msg []byte) (ECDSASignature, error) {
+	hashed := sha256.Sum256(msg)
+	max := new(big.Int).Rsh(b.priv.N, b.zeroBits)
+	for {
+		k, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return ECDSASignature{}, fmt.Errorf("failed to generate nonce: %v", err)
+		}
+		if k.Sign() == 0 {
+			continue
+		}
+		r, s, err := ec.SignWithNonce(b.priv, hashed[:], k.Lsh(k, b.zeroBits))
+		if err == nil {
+			return ECDSASignature{Hashed: hashed[:], R: r, S: s}, nil
+		}
+	}
+}
+
+// C62 solution
+func C62() {
+	fmt.Println("---------------------- c62 ------------------------")
+	signer, err := NewBiasedSigner(ec.Set8Curve, c62ZeroBits)
+	if err != nil {
+		log.Fatalf("failed to create signer: %v", err)
+	}
+	var sigs []ECDSASignature
+	for i := 0; i < c62Signatures; i++ {
+		sig, err := signer.Sign([]byte(fmt.Sprintf("message %v", i)))
+		if err != nil {
+			log.Fatalf("failed to sign: %v", err)
+		}
+		sigs = append(sigs, sig)
+	}
+
+	d, err := BiasedNonceAttack(signer.PublicKey(), sigs, c62ZeroBits)
+	if err != nil {
+		log.Fatalf("attack failed: %v", err)
+	}
+	fmt.Printf("Recovered private key %v from %v signatures (correct? %v)\n", d, len(sigs), d.Cmp(signer.priv.D) == 0)
+}
+
+// BiasedNonceAttack recovers the private key d from signatures whose
+// nonces have their low zeroBits bits set to zero.
+//
+// With k = 2^l * b, s = (H + d*r) / k gives b = d*t - u mod n, for
+// t = r / (2^l * s) and u = -H / (2^l * s). Since b < n / 2^l, each
+// signature makes d*t_i - u_i unusually small mod n. In the lattice
+// spanned by the rows
+//
+//	n   0   ...  0    0    0
+//	...
+//	0   0   ...  n    0    0
+//	t1  t2  ...  tm   ct   0
+//	u1  u2  ...  um   0    cu
+//
+// with ct = 1/2^l and cu = n/2^l, the vector d*bt - bu + sum(m_i*b_i) =
+// (b_1, ..., b_m, d/2^l, -cu) is short, so LLL tends to find it.
+func BiasedNonceAttack(pub *ec.PublicKey, sigs []ECDSASignature, zeroBits uint) (*big.Int, error) {
+	m := len(sigs)
+	scale := new(big.Int).Lsh(big.NewInt(1), zeroBits)
+	ct := new(big.Rat).SetFrac(big.NewInt(1), scale)
+	cu := new(big.Rat).SetFrac(pub.N, scale)
+
+	basis := make([]lattice.Vector, m+2)
+	for i := range basis {
+		basis[i] = make(lattice.Vector, m+2)
+		for j := range basis[i] {
+			basis[i][j] = new(big.Rat)
+		}
+	}
+	for i, sig := range sigs {
+		basis[i][i].SetInt(pub.N)
+
+		// 1 / (2^l * s)
+		w := new(big.Int).Mul(scale, sig.S)
+		if w.ModInverse(w, pub.N) == nil {
+			return nil, fmt.Errorf("signature %v has s not invertible mod n", i)
+		}
+		t := new(big.Int).Mul(sig.R, w)
+		basis[m][i].SetInt(t.Mod(t, pub.N))
+		u := new(big.Int).Neg(dsa.HashToInt(sig.Hashed, pub.N))
+		u.Mul(u, w)
+		basis[m+1][i].SetInt(u.Mod(u, pub.N))
+	}
+	basis[m][m].Set(ct)
+	basis[m+1][m+1].Set(cu)
+
+	negCu := new(big.Rat).Neg(cu)
+	for _, v := range lattice.LLL(basis, big.NewRat(99, 100)) {
+		var d *big.Rat
+		switch {
+		case v[m+1].Cmp(negCu) == 0:
+			d = new(big.Rat).Mul(v[m], new(big.Rat).SetInt(scale))
+		case v[m+1].Cmp(cu) == 0:
+			d = new(big.Rat).Mul(v[m], new(big.Rat).SetInt(scale))
+			d.Neg(d)
+		default:
+			continue
+		}
+		if !d.IsInt() {
+			continue
+		}
+		x := new(big.Int).Mod(d.Num(), pub.N)
+		if pub.ScalarBaseMult(x).Equal(pub.Q) {
+			return x, nil
+		}
+	}
+	return nil, fmt.Errorf("no reduced basis vector yields the private key")
+}
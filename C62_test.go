@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/ec"
+)
+
+// A 48 bit curve of prime order, small enough for quick lattice reduction
+var c62TestCurve = ec.Curve{
+	P: big.NewInt(207272659594291),
+	A: big.NewInt(197910629159462),
+	B: big.NewInt(80644620617635),
+	G: ec.NewPoint(87575408378861, 166683536514624),
+	N: big.NewInt(207272676774949),
+}
+
+func TestC62TestCurve(t *testing.T) {
+	c := c62TestCurve
+	if !c.P.ProbablyPrime(20) || !c.N.ProbablyPrime(20) {
+		t.Fatalf("p and n must be prime")
+	}
+	if !c.IsOnCurve(c.G) {
+		t.Fatalf("base point is not on the curve")
+	}
+	if !c.ScalarBaseMult(c.N).IsInfinity() {
+		t.Errorf("base point does not have order n")
+	}
+}
+
+func TestBiasedNonceAttack(t *testing.T) {
+	ex := []struct {
+		curve    ec.Curve
+		zeroBits uint
+		count    int
+	}{
+		{c62TestCurve, 8, 20},
+		{c62TestCurve, 4, 20},
+		{ec.Set8Curve, 8, 22},
+	}
+	for _, e := range ex {
+		if testing.Short() && e.curve.N.BitLen() > 64 {
+			continue
+		}
+		signer, err := NewBiasedSigner(e.curve, e.zeroBits)
+		if err != nil {
+			t.Fatalf("failed to create signer: %v", err)
+		}
+		var sigs []ECDSASignature
+		for i := 0; i < e.count; i++ {
+			sig, err := signer.Sign([]byte(fmt.Sprintf("message %v", i)))
+			if err != nil {
+				t.Fatalf("failed to sign: %v", err)
+			}
+			if !ec.Verify(signer.PublicKey(), sig.Hashed, sig.R, sig.S) {
+				t.Fatalf("biased signature does not verify")
+			}
+			sigs = append(sigs, sig)
+		}
+		d, err := BiasedNonceAttack(signer.PublicKey(), sigs, e.zeroBits)
+		if err != nil {
+			t.Fatalf("attack with %v zero bits failed: %v", e.zeroBits, err)
+		}
+		if d.Cmp(signer.priv.D) != 0 {
+			t.Errorf("Biased nonce attack failed: \nExp: %v \nGot: %v", signer.priv.D, d)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"log"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+// GCMMessage is a message sealed with GCM, with the tag split off
+type GCMMessage struct {
+	AAD        []byte
+	Ciphertext []byte
+	Tag        []byte
+}
+
+// sealGCM seals the plaintext and splits off the tag
+func sealGCM(aead cipher.AEAD, nonce, plaintext, aad []byte) GCMMessage {
+	sealed := aead.Seal(nil, nonce, plaintext, aad)
+	n := len(sealed) - aead.Overhead()
+	return GCMMessage{AAD: aad, Ciphertext: sealed[:n], Tag: sealed[n:]}
+}
+
+// C63 solution
+func C63() {
+	fmt.Println("---------------------- c63 ------------------------")
+	key, err := pals.GenerateRandomBytes(keySize)
+	if err != nil {
+		log.Fatalf("failed to generate key: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		log.Fatalf("failed to create cipher: %v", err)
+	}
+	aead, err := pals.NewGCM(block)
+	if err != nil {
+		log.Fatalf("failed to create GCM: %v", err)
+	}
+
+	// The sender reuses a nonce
+	nonce, err := pals.GenerateRandomBytes(aead.NonceSize())
+	if err != nil {
+		log.Fatalf("failed to generate nonce: %v", err)
+	}
+	msgs := []GCMMessage{
+		sealGCM(aead, nonce, []byte("Transfer $100 to Bob, love Alice"), []byte("from=alice")),
+		sealGCM(aead, nonce, []byte("Lunch at noon?"), []byte("from=alice")),
+		sealGCM(aead, nonce, []byte("Remember to water the plants"), []byte("from=alice")),
+	}
+
+	candidates, err := RecoverAuthKey(msgs)
+	if err != nil {
+		log.Fatalf("failed to recover authentication key: %v", err)
+	}
+	fmt.Printf("Found %v candidate(s) for H: %x\n", len(candidates), candidates[0].Bytes())
+
+	// Turn $100 into $900 by flipping ciphertext bits, and fix up the tag
+	forged := append([]byte{}, msgs[0].Ciphertext...)
+	forged[10] ^= '1' ^ '9'
+	tag := ForgeGCMTag(candidates[0], msgs[0], msgs[0].AAD, forged)
+	plain, err := aead.Open(nil, nonce, append(forged, tag...), msgs[0].AAD)
+	if err != nil {
+		log.Fatalf("forged message was rejected: %v", err)
+	}
+	fmt.Printf("Forged message accepted: %q\n", plain)
+}
+
+// ghashPoly returns GHASH(A, C) + tag as a polynomial in H. With blocks
+// b_1 .. b_n, including the length block, that's b_1*H^n + ... + b_n*H + t.
+func ghashPoly(m GCMMessage) pals.GF128Poly {
+	var blocks []pals.GF128
+	for _, data := range [][]byte{m.AAD, m.Ciphertext} {
+		for i := 0; i < len(data); i += 16 {
+			block := make([]byte, 16)
+			copy(block, data[i:])
+			blocks = append(blocks, pals.GF128FromBytes(block))
+		}
+	}
+	lengths := make([]byte, 16)
+	binary.BigEndian.PutUint64(lengths[:8], uint64(len(m.AAD))*8)
+	binary.BigEndian.PutUint64(lengths[8:], uint64(len(m.Ciphertext))*8)
+	blocks = append(blocks, pals.GF128FromBytes(lengths))
+
+	coeffs := make([]pals.GF128, len(blocks)+1)
+	coeffs[0] = pals.GF128FromBytes(m.Tag)
+	for i, b := range blocks {
+		coeffs[len(blocks)-i] = b
+	}
+	return pals.NewGF128Poly(coeffs...)
+}
+
+// RecoverAuthKey recovers candidates for the authentication key H from
+// messages sealed under the same key and nonce.
+//
+// Every tag is t = GHASH(H, A, C) + E(K, J0), and with a repeated nonce the
+// mask E(K, J0) is the same for all of them. Adding two of the polynomials
+// GHASH(A, C) + t in H cancels the mask, so H is one of their roots. Each
+// further message narrows down the candidates.
+func RecoverAuthKey(msgs []GCMMessage) ([]pals.GF128, error) {
+	if len(msgs) < 2 {
+		return nil, fmt.Errorf("need at least two messages, got %v", len(msgs))
+	}
+	first := ghashPoly(msgs[0])
+	var candidates []pals.GF128
+	for i, m := range msgs[1:] {
+		roots, err := first.Add(ghashPoly(m)).Roots(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			candidates = roots
+			continue
+		}
+		var common []pals.GF128
+		for _, c := range candidates {
+			for _, r := range roots {
+				if c == r {
+					common = append(common, c)
+					break
+				}
+			}
+		}
+		candidates = common
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no candidates for H; was the nonce reused?")
+	}
+	return candidates, nil
+}
+
+// ForgeGCMTag computes a valid tag for aad and ciphertext given the
+// authentication key h and a known message sealed under the same nonce.
+// The mask is s = t + GHASH(H, A, C) of the known message.
+func ForgeGCMTag(h pals.GF128, known GCMMessage, aad, ciphertext []byte) []byte {
+	s := pals.GHASH(h, known.AAD, known.Ciphertext).Add(pals.GF128FromBytes(known.Tag))
+	return pals.GHASH(h, aad, ciphertext).Add(s).Bytes()
+}
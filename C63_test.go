@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"testing"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+)
+
+func TestRecoverAuthKey(t *testing.T) {
+	key := []byte("YELLOW SUBMARINE")
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+	aead, err := pals.NewGCM(block)
+	if err != nil {
+		t.Fatalf("failed to create GCM: %v", err)
+	}
+	h := make([]byte, 16)
+	block.Encrypt(h, h)
+
+	nonce := []byte("reused nonce")
+	msgs := []GCMMessage{
+		sealGCM(aead, nonce, []byte("Transfer $100 to Bob, love Alice"), []byte("from=alice")),
+		sealGCM(aead, nonce, []byte("Lunch at noon?"), nil),
+		sealGCM(aead, nonce, []byte("Remember to water the plants"), []byte("from=alice; to=bob")),
+	}
+	candidates, err := RecoverAuthKey(msgs)
+	if err != nil {
+		t.Fatalf("failed to recover H: %v", err)
+	}
+	if len(candidates) != 1 || !bytes.Equal(candidates[0].Bytes(), h) {
+		t.Fatalf("RecoverAuthKey failed: \nExp: %x \nGot: %v candidates", h, len(candidates))
+	}
+
+	forged := []byte("Transfer $900 to Eve, love Alice")
+	diff := pals.XorFixed(forged, []byte("Transfer $100 to Bob, love Alice"))
+	crypt := pals.XorFixed(msgs[0].Ciphertext, diff)
+	tag := ForgeGCMTag(candidates[0], msgs[0], []byte("from=alice"), crypt)
+	plain, err := aead.Open(nil, nonce, append(crypt, tag...), []byte("from=alice"))
+	if err != nil {
+		t.Fatalf("Forged message was rejected: %v", err)
+	}
+	if !bytes.Equal(plain, forged) {
+		t.Errorf("Forgery failed: \nExp: %q \nGot: %q", forged, plain)
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"log"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals"
+	"github.com/ExalDraen/cryptopals-challenges/pals/bitmat"
+)
+
+// The challenge's 32 bit tags and 2^17 block messages. The attack needs
+// about 2^(tag bits - n + 1) forgery attempts of 2^n blocks each before its
+// first success, so this takes around 2^33 block multiplications, a few
+// minutes.
+const (
+	c64TagSize  = 4
+	c64LogBlock = 17
+)
+
+// GCMOracle decrypts messages sealed with truncated GCM tags under a fixed
+// key and nonce, and only reveals whether they were accepted
+type GCMOracle struct {
+	block cipher.Block
+	aead  cipher.AEAD
+	nonce []byte
+}
+
+// NewGCMOracle creates an oracle with a random key, using tags of tagSize
+// bytes
+func NewGCMOracle(tagSize int) (*GCMOracle, error) {
+	key, err := pals.GenerateRandomBytes(keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	aead, err := pals.NewGCMWithTagSize(block, tagSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %v", err)
+	}
+	nonce, err := pals.GenerateRandomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+	return &GCMOracle{block: block, aead: aead, nonce: nonce}, nil
+}
+
+// Seal encrypts the plaintext, as a sender would
+func (o *GCMOracle) Seal(plaintext []byte) GCMMessage {
+	return sealGCM(o.aead, o.nonce, plaintext, nil)
+}
+
+// Accepts reports whether the ciphertext and tag authenticate
+func (o *GCMOracle) Accepts(ciphertext, tag []byte) bool {
+	sealed := make([]byte, 0, len(ciphertext)+len(tag))
+	sealed = append(append(sealed, ciphertext...), tag...)
+	_, err := o.aead.Open(nil, o.nonce, sealed, nil)
+	return err == nil
+}
+
+// authKey returns H = E(K, 0), to check the attack's result
+func (o *GCMOracle) authKey() pals.GF128 {
+	h := make([]byte, aes.BlockSize)
+	o.block.Encrypt(h, h)
+	return pals.GF128FromBytes(h)
+}
+
+// C64 solution
+func C64() {
+	fmt.Println("---------------------- c64 ------------------------")
+	oracle, err := NewGCMOracle(c64TagSize)
+	if err != nil {
+		log.Fatalf("failed to create oracle: %v", err)
+	}
+	msg := oracle.Seal(make([]byte, aes.BlockSize<<c64LogBlock))
+
+	h, queries, err := TruncatedTagAttack(oracle, msg)
+	if err != nil {
+		log.Fatalf("attack failed: %v", err)
+	}
+	fmt.Printf("Recovered H %x after %v queries (correct? %v)\n", h.Bytes(), queries, h == oracle.authKey())
+}
+
+// TruncatedTagAttack recovers the authentication key H using forgeries of
+// a message of 2^n blocks, and returns it along with the number of oracle
+// queries made. The tag size is taken from the message, and the first
+// forgery needs about 2^(tag bits - n + 1) queries.
+//
+// Squaring is linear over GF(2), so adding d_i to the block multiplied by
+// H^(2^i) changes the tag by the error e = sum(d_i * H^(2^i)) = Ad * H, for
+// a 128x128 bit matrix Ad depending only on the d_i. Choosing the d_i in the
+// kernel of the map to the first k rows of Ad zeroes k bits of the error,
+// and only the remaining tag bits need luck. Each accepted forgery shows that
+// the remaining rows of Ad are orthogonal to H. Collecting those rows in K
+// confines H to the kernel of K, with basis X, so we only need Ad * X to
+// vanish, which lets us zero more rows at a time until H is pinned down.
+func TruncatedTagAttack(oracle *GCMOracle, msg GCMMessage) (pals.GF128, int, error) {
+	blocks := len(msg.Ciphertext) / aes.BlockSize
+	n := 0
+	for 1<<uint(n) < blocks {
+		n++
+	}
+	if len(msg.Ciphertext) != aes.BlockSize<<uint(n) || n < 2 {
+		return pals.GF128{}, 0, fmt.Errorf("message must have a power of two blocks, got %v bytes", len(msg.Ciphertext))
+	}
+	tagBits := len(msg.Tag) * 8
+	cols := n * 128
+
+	// powers[b] is x^b, and squares[j][i] is (x^j)^(2^i) for i in 1..n,
+	// so Ad has column j = sum(d_i * squares[j][i])
+	powers := make([]pals.GF128, 128)
+	squares := make([][]pals.GF128, 128)
+	for j := range powers {
+		powers[j] = pals.GF128{}.SetCoefficient(j, 1)
+		squares[j] = squarings(powers[j], n)
+	}
+
+	queries := 0
+	k := bitmat.New(0, 128)
+	ct := append([]byte{}, msg.Ciphertext...)
+	for {
+		x := k.Kernel()
+		dim := x.Rows()
+		if dim == 0 {
+			return pals.GF128{}, queries, fmt.Errorf("no candidates left for H")
+		}
+		if dim == 1 {
+			return vectorToGF128(x.Row(0)), queries, nil
+		}
+
+		// Zero as many rows of Ad * X as we can while leaving a kernel,
+		// but at least one tag bit to learn from
+		zeroed := (cols - 1) / dim
+		if zeroed > tagBits-1 {
+			zeroed = tagBits - 1
+		}
+		basis := make([][]pals.GF128, dim)
+		for c := range basis {
+			basis[c] = squarings(vectorToGF128(x.Row(c)), n)
+		}
+		// t maps the bits of the d_i to the first rows of Ad * X. Column
+		// c of Ad * X is sum(d_i * X_c^(2^i)).
+		t := bitmat.New(zeroed*dim, cols)
+		for i := 1; i <= n; i++ {
+			for b := 0; b < 128; b++ {
+				col := (i-1)*128 + b
+				for c := range basis {
+					v := powers[b].Mul(basis[c][i])
+					for r := 0; r < zeroed; r++ {
+						if v.Coefficient(r) == 1 {
+							t.Set(r*dim+c, col, 1)
+						}
+					}
+				}
+			}
+		}
+		ds := t.Kernel()
+		if ds.Rows() == 0 {
+			return pals.GF128{}, queries, fmt.Errorf("no forgeries zero %v rows", zeroed)
+		}
+
+		for {
+			d, err := randomCombination(ds)
+			if err != nil {
+				return pals.GF128{}, queries, err
+			}
+			diffs := make([]pals.GF128, n+1)
+			for i := 1; i <= n; i++ {
+				for b := 0; b < 128; b++ {
+					diffs[i] = diffs[i].SetCoefficient(b, d.Get((i-1)*128+b))
+				}
+			}
+
+			applyDiffs(ct, diffs)
+			queries++
+			ok := oracle.Accepts(ct, msg.Tag)
+			applyDiffs(ct, diffs)
+			if !ok {
+				continue
+			}
+
+			// The tag bits past the zeroed rows were zero too
+			ad := bitmat.New(tagBits, 128)
+			for j := 0; j < 128; j++ {
+				var v pals.GF128
+				for i := 1; i <= n; i++ {
+					v = v.Add(diffs[i].Mul(squares[j][i]))
+				}
+				for r := zeroed; r < tagBits; r++ {
+					ad.Set(r, j, v.Coefficient(r))
+				}
+			}
+			for r := zeroed; r < tagBits; r++ {
+				if !ad.Row(r).IsZero() {
+					k.AppendRow(ad.Row(r))
+				}
+			}
+			break
+		}
+	}
+}
+
+// squarings returns a^(2^i) for i in 0..n
+func squarings(a pals.GF128, n int) []pals.GF128 {
+	out := make([]pals.GF128, n+1)
+	out[0] = a
+	for i := 1; i <= n; i++ {
+		out[i] = out[i-1].Mul(out[i-1])
+	}
+	return out
+}
+
+// applyDiffs adds diffs[i] to the ciphertext block multiplied by H^(2^i).
+// With m blocks followed by the length block, block j (from 1) is
+// multiplied by H^(m+2-j).
+func applyDiffs(ct []byte, diffs []pals.GF128) {
+	m := len(ct) / aes.BlockSize
+	for i := 1; i < len(diffs); i++ {
+		off := (m + 1 - 1<<uint(i)) * aes.BlockSize
+		copy(ct[off:], pals.XorFixed(ct[off:off+aes.BlockSize], diffs[i].Bytes()))
+	}
+}
+
+// randomCombination returns a random non-zero sum of the rows of m
+func randomCombination(m *bitmat.Matrix) (bitmat.Vector, error) {
+	for {
+		r, err := pals.GenerateRandomBytes((m.Rows() + 7) / 8)
+		if err != nil {
+			return bitmat.Vector{}, fmt.Errorf("failed to generate random bits: %v", err)
+		}
+		v := bitmat.NewVector(m.Cols())
+		for i := 0; i < m.Rows(); i++ {
+			if r[i/8]>>(uint(i)%8)&1 == 1 {
+				v.Add(m.Row(i))
+			}
+		}
+		if !v.IsZero() {
+			return v, nil
+		}
+	}
+}
+
+// vectorToGF128 reads a vector of 128 bits as the coefficients of an element
+func vectorToGF128(v bitmat.Vector) pals.GF128 {
+	var a pals.GF128
+	for i := 0; i < 128; i++ {
+		a = a.SetCoefficient(i, v.Get(i))
+	}
+	return a
+}
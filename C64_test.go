@@ -0,0 +1,42 @@
+package main
+
+import (
+	"crypto/aes"
+	"testing"
+)
+
+func TestTruncatedTagAttack(t *testing.T) {
+	ex := []struct {
+		tagSize  int
+		logBlock uint
+	}{
+		{2, 9},
+		{2, 6},
+	}
+	for _, e := range ex {
+		testTruncatedTagAttack(t, e.tagSize, e.logBlock)
+	}
+}
+
+func TestTruncatedTagAttack32(t *testing.T) {
+	if testing.Short() {
+		t.Skip("needs around 2^33 block multiplications")
+	}
+	testTruncatedTagAttack(t, c64TagSize, c64LogBlock)
+}
+
+func testTruncatedTagAttack(t *testing.T, tagSize int, logBlock uint) {
+	oracle, err := NewGCMOracle(tagSize)
+	if err != nil {
+		t.Fatalf("failed to create oracle: %v", err)
+	}
+	msg := oracle.Seal(make([]byte, aes.BlockSize<<logBlock))
+	h, queries, err := TruncatedTagAttack(oracle, msg)
+	if err != nil {
+		t.Fatalf("attack failed: %v", err)
+	}
+	if h != oracle.authKey() {
+		t.Errorf("Truncated tag attack (tag bits: %v) failed after %v queries: \nExp: %x \nGot: %x", tagSize*8, queries, oracle.authKey().Bytes(), h.Bytes())
+	}
+	t.Logf("Recovered H with %v bit tags after %v queries", tagSize*8, queries)
+}
@@ -2,5 +2,9 @@ package main
 
 func main() {
 	//Set1()
-	Set2()
+	//Set2()
+	//Set5()
+	//Set6()
+	//Set7()
+	Set8()
 }
@@ -0,0 +1,282 @@
+// Package bitmat implements dense vectors and matrices over GF(2). Rows
+// are packed 64 entries to a word, so adding one row to another is a
+// handful of XORs.
+package bitmat
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// Vector is a vector over GF(2)
+type Vector struct {
+	n    int
+	bits []uint64
+}
+
+// NewVector returns the zero vector of length n
+func NewVector(n int) Vector {
+	return Vector{n: n, bits: make([]uint64, (n+63)/64)}
+}
+
+// Len returns the length of v
+func (v Vector) Len() int {
+	return v.n
+}
+
+// Get returns entry i
+func (v Vector) Get(i int) uint {
+	return uint(v.bits[i/64]>>(uint(i)%64)) & 1
+}
+
+// Set sets entry i to b
+func (v Vector) Set(i int, b uint) {
+	mask := uint64(1) << (uint(i) % 64)
+	if b&1 == 1 {
+		v.bits[i/64] |= mask
+	} else {
+		v.bits[i/64] &^= mask
+	}
+}
+
+// Add adds w to v in place
+func (v Vector) Add(w Vector) {
+	for i := range v.bits {
+		v.bits[i] ^= w.bits[i]
+	}
+}
+
+// Dot returns the dot product of v and w
+func (v Vector) Dot(w Vector) uint {
+	var acc uint64
+	for i := range v.bits {
+		acc ^= v.bits[i] & w.bits[i]
+	}
+	return uint(bits.OnesCount64(acc)) & 1
+}
+
+// IsZero reports whether v is the zero vector
+func (v Vector) IsZero() bool {
+	for _, w := range v.bits {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of v
+func (v Vector) Clone() Vector {
+	return Vector{n: v.n, bits: append([]uint64{}, v.bits...)}
+}
+
+func (v Vector) String() string {
+	var sb strings.Builder
+	for i := 0; i < v.n; i++ {
+		sb.WriteByte(byte('0' + v.Get(i)))
+	}
+	return sb.String()
+}
+
+// Matrix is a matrix over GF(2), stored as packed rows
+type Matrix struct {
+	rows []Vector
+	cols int
+}
+
+// New returns the zero matrix with the given dimensions
+func New(rows, cols int) *Matrix {
+	m := &Matrix{rows: make([]Vector, rows), cols: cols}
+	for i := range m.rows {
+		m.rows[i] = NewVector(cols)
+	}
+	return m
+}
+
+// Identity returns the n by n identity matrix
+func Identity(n int) *Matrix {
+	m := New(n, n)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, 1)
+	}
+	return m
+}
+
+// Rows returns the number of rows of m
+func (m *Matrix) Rows() int {
+	return len(m.rows)
+}
+
+// Cols returns the number of columns of m
+func (m *Matrix) Cols() int {
+	return m.cols
+}
+
+// Get returns the entry in row i and column j
+func (m *Matrix) Get(i, j int) uint {
+	return m.rows[i].Get(j)
+}
+
+// Set sets the entry in row i and column j to b
+func (m *Matrix) Set(i, j int, b uint) {
+	m.rows[i].Set(j, b)
+}
+
+// Row returns row i. It shares storage with m.
+func (m *Matrix) Row(i int) Vector {
+	return m.rows[i]
+}
+
+// AppendRow adds a copy of v as the last row of m
+func (m *Matrix) AppendRow(v Vector) {
+	if v.Len() != m.cols {
+		panic(fmt.Sprintf("bitmat: appending row of length %v to matrix with %v columns", v.Len(), m.cols))
+	}
+	m.rows = append(m.rows, v.Clone())
+}
+
+// AddRow adds row src to row dst
+func (m *Matrix) AddRow(dst, src int) {
+	m.rows[dst].Add(m.rows[src])
+}
+
+// SwapRows swaps rows i and j
+func (m *Matrix) SwapRows(i, j int) {
+	m.rows[i], m.rows[j] = m.rows[j], m.rows[i]
+}
+
+// Clone returns a copy of m
+func (m *Matrix) Clone() *Matrix {
+	c := &Matrix{rows: make([]Vector, len(m.rows)), cols: m.cols}
+	for i, r := range m.rows {
+		c.rows[i] = r.Clone()
+	}
+	return c
+}
+
+// Equal reports whether m and n are the same matrix
+func (m *Matrix) Equal(n *Matrix) bool {
+	if m.Rows() != n.Rows() || m.cols != n.cols {
+		return false
+	}
+	for i := range m.rows {
+		for j := range m.rows[i].bits {
+			if m.rows[i].bits[j] != n.rows[i].bits[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Transpose returns the transpose of m
+func (m *Matrix) Transpose() *Matrix {
+	t := New(m.cols, m.Rows())
+	for i := range m.rows {
+		for j := 0; j < m.cols; j++ {
+			if m.Get(i, j) == 1 {
+				t.Set(j, i, 1)
+			}
+		}
+	}
+	return t
+}
+
+// Mul returns the product a * b. Row i of the product is the sum of the
+// rows of b selected by row i of a.
+func Mul(a, b *Matrix) *Matrix {
+	if a.cols != b.Rows() {
+		panic(fmt.Sprintf("bitmat: multiplying %vx%v by %vx%v matrix", a.Rows(), a.cols, b.Rows(), b.cols))
+	}
+	p := New(a.Rows(), b.cols)
+	for i, r := range a.rows {
+		for j := 0; j < a.cols; j++ {
+			if r.Get(j) == 1 {
+				p.rows[i].Add(b.rows[j])
+			}
+		}
+	}
+	return p
+}
+
+// MulVec returns the product m * v
+func (m *Matrix) MulVec(v Vector) Vector {
+	if v.Len() != m.cols {
+		panic(fmt.Sprintf("bitmat: multiplying %vx%v matrix by vector of length %v", m.Rows(), m.cols, v.Len()))
+	}
+	p := NewVector(m.Rows())
+	for i, r := range m.rows {
+		p.Set(i, r.Dot(v))
+	}
+	return p
+}
+
+// Reduce brings m into reduced row echelon form in place using Gaussian
+// elimination, and returns the pivot column of each non-zero row. The
+// rank of m is the number of pivots.
+func (m *Matrix) Reduce() []int {
+	var pivots []int
+	r := 0
+	for c := 0; c < m.cols && r < len(m.rows); c++ {
+		p := -1
+		for i := r; i < len(m.rows); i++ {
+			if m.rows[i].Get(c) == 1 {
+				p = i
+				break
+			}
+		}
+		if p < 0 {
+			continue
+		}
+		m.SwapRows(r, p)
+		for i := range m.rows {
+			if i != r && m.rows[i].Get(c) == 1 {
+				m.AddRow(i, r)
+			}
+		}
+		pivots = append(pivots, c)
+		r++
+	}
+	return pivots
+}
+
+// Rank returns the rank of m
+func (m *Matrix) Rank() int {
+	return len(m.Clone().Reduce())
+}
+
+// Kernel returns a basis of the kernel of m, the vectors v with m*v = 0,
+// as the rows of a matrix
+func (m *Matrix) Kernel() *Matrix {
+	r := m.Clone()
+	pivots := r.Reduce()
+	isPivot := make([]bool, m.cols)
+	for _, p := range pivots {
+		isPivot[p] = true
+	}
+
+	// Each free column f gives a basis vector with v_f = 1, where each
+	// pivot variable cancels its row's entry in column f
+	k := New(0, m.cols)
+	for f := 0; f < m.cols; f++ {
+		if isPivot[f] {
+			continue
+		}
+		v := NewVector(m.cols)
+		v.Set(f, 1)
+		for i, p := range pivots {
+			v.Set(p, r.Get(i, f))
+		}
+		k.AppendRow(v)
+	}
+	return k
+}
+
+func (m *Matrix) String() string {
+	var rows []string
+	for _, r := range m.rows {
+		rows = append(rows, r.String())
+	}
+	return strings.Join(rows, "\n")
+}
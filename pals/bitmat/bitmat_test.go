@@ -0,0 +1,90 @@
+package bitmat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomMatrix(rng *rand.Rand, rows, cols int) *Matrix {
+	m := New(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			m.Set(i, j, uint(rng.Intn(2)))
+		}
+	}
+	return m
+}
+
+func TestMul(t *testing.T) {
+	rng := rand.New(rand.NewSource(49))
+	a := randomMatrix(rng, 70, 90)
+	b := randomMatrix(rng, 90, 65)
+	c := randomMatrix(rng, 65, 3)
+	if !Mul(Mul(a, b), c).Equal(Mul(a, Mul(b, c))) {
+		t.Errorf("Mul is not associative")
+	}
+	if !Mul(a, Identity(90)).Equal(a) || !Mul(Identity(70), a).Equal(a) {
+		t.Errorf("Identity is not the identity")
+	}
+	// (AB)^T = B^T A^T
+	if !Mul(a, b).Transpose().Equal(Mul(b.Transpose(), a.Transpose())) {
+		t.Errorf("Transpose does not agree with Mul")
+	}
+
+	v := NewVector(3)
+	v.Set(0, 1)
+	v.Set(2, 1)
+	col := c.MulVec(v)
+	for i := 0; i < c.Rows(); i++ {
+		if col.Get(i) != c.Get(i, 0)^c.Get(i, 2) {
+			t.Fatalf("MulVec failed in row %v", i)
+		}
+	}
+}
+
+func TestKernel(t *testing.T) {
+	rng := rand.New(rand.NewSource(49))
+	ex := []struct {
+		rows, cols int
+	}{
+		{10, 10},
+		{64, 130},
+		{130, 200},
+		{200, 130},
+	}
+	for _, e := range ex {
+		m := randomMatrix(rng, e.rows, e.cols)
+		// Make some rows dependent
+		for i := 0; i < e.rows/4; i++ {
+			m.AddRow(rng.Intn(e.rows), rng.Intn(e.rows))
+		}
+		rank := m.Rank()
+		k := m.Kernel()
+		if k.Rows() != e.cols-rank {
+			t.Errorf("Kernel of %vx%v matrix has dimension %v, expected %v", e.rows, e.cols, k.Rows(), e.cols-rank)
+		}
+		if k.Rank() != k.Rows() {
+			t.Errorf("Kernel basis of %vx%v matrix is not independent", e.rows, e.cols)
+		}
+		for i := 0; i < k.Rows(); i++ {
+			if !m.MulVec(k.Row(i)).IsZero() {
+				t.Errorf("Kernel vector %v of %vx%v matrix is not in the kernel", i, e.rows, e.cols)
+			}
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	// Rows 0 and 1 add up to row 2
+	m := New(3, 4)
+	for _, e := range [][2]int{{0, 0}, {0, 2}, {1, 1}, {1, 2}, {2, 0}, {2, 1}} {
+		m.Set(e[0], e[1], 1)
+	}
+	pivots := m.Reduce()
+	if len(pivots) != 2 || pivots[0] != 0 || pivots[1] != 1 {
+		t.Errorf("Reduce failed: expected pivots [0 1], got %v", pivots)
+	}
+	if !m.Row(2).IsZero() {
+		t.Errorf("Reduce failed: dependent row is not zero:\n%v", m)
+	}
+}
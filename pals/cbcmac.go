@@ -0,0 +1,24 @@
+package pals
+
+import (
+	"crypto/aes"
+	"fmt"
+)
+
+// CBCMAC computes the CBC-MAC of msg under the given AES key and IV.
+// The message is PKCS7 padded and CBC encrypted; the MAC is the
+// last cypher block.
+func CBCMAC(key, iv, msg []byte) ([]byte, error) {
+	cypher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate cypher with key %v: %v", key, err)
+	}
+	if len(iv) != cypher.BlockSize() {
+		return nil, fmt.Errorf("IV length %v does not match block size %v", len(iv), cypher.BlockSize())
+	}
+
+	padded := PadPKCS7(msg, cypher.BlockSize())
+	dst := make([]byte, len(padded))
+	NewCBCEncrypter(cypher, iv).CryptBlocks(dst, padded)
+	return dst[len(dst)-cypher.BlockSize():], nil
+}
@@ -0,0 +1,41 @@
+package pals
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestCBCMAC(t *testing.T) {
+	ex := []struct {
+		key      []byte
+		iv       []byte
+		input    []byte
+		expected string
+	}{
+		// CBC-MAC equals the last block of the CBC encryption
+		{[]byte("YELLOW SUBMARINE"), make([]byte, 16), []byte("alert('MZA who was that?');\n"), "296b8d7cb78a243dda4d0a61d33bbdd1"},
+	}
+
+	for _, e := range ex {
+		result, err := CBCMAC(e.key, e.iv, e.input)
+		if err != nil {
+			t.Fatalf("CBCMAC failed: %v", err)
+		}
+		if hex.EncodeToString(result) != e.expected {
+			t.Errorf("CBCMAC of %q failed: \nExp: %v \nGot: %x", e.input, e.expected, result)
+		}
+	}
+
+	// Changing the IV changes the MAC
+	key := []byte("YELLOW SUBMARINE")
+	iv := bytes.Repeat([]byte{1}, 16)
+	a, _ := CBCMAC(key, make([]byte, 16), []byte("hi mom"))
+	b, _ := CBCMAC(key, iv, []byte("hi mom"))
+	if bytes.Equal(a, b) {
+		t.Errorf("CBCMAC ignores IV")
+	}
+	if !bytes.Equal(iv, bytes.Repeat([]byte{1}, 16)) {
+		t.Errorf("CBCMAC modified the IV")
+	}
+}
@@ -0,0 +1,76 @@
+// Package dh implements finite field Diffie-Hellman key exchange. Public keys
+// received from the peer are deliberately not validated, so that attacks
+// relying on that can be demonstrated.
+package dh
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// Group represents the Diffie-Hellman domain parameters: a prime p and a
+// generator g of a subgroup of prime order q
+type Group struct {
+	P, G, Q *big.Int
+}
+
+// PrivateKey represents a Diffie-Hellman key pair
+type PrivateKey struct {
+	Group
+	X *big.Int // secret exponent
+	Y *big.Int // public key g^x mod p
+}
+
+// Set8Group is the group used by the cryptopals set 8 challenges.
+// p-1 = j*q, where j has many small factors.
+var Set8Group = Group{
+	P: fromDecimal("7199773997391911030609999317773941274322764333428698921736339643928346453700085358802973900485592910475480089726140708102474957429903531369589969318716771"),
+	G: fromDecimal("4565356397095740655436854503483826832136106141639563487732438195343690437606117828318042418238184896212352329118608100083187535033402010599512641674644143"),
+	Q: fromDecimal("236234353446506858198510045061214171961"),
+}
+
+// KangarooGroup is the group used by the cryptopals challenge 58. Unlike
+// Set8Group, the small factors of its cofactor only cover part of q.
+var KangarooGroup = Group{
+	P: fromDecimal("11470374874925275658116663507232161402086650258453896274534991676898999262641581519101074740642369848233294239851519212341844337347119899874391456329785623"),
+	G: fromDecimal("622952335333961296978159266084741085889881358738459939978290179936063635566740258555167783009058567397963466103140082647486611657350811560630587013183357"),
+	Q: fromDecimal("335062023296420808191071248367701059461"),
+}
+
+func fromDecimal(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("dh: invalid decimal constant " + s)
+	}
+	return n
+}
+
+// GenerateKey generates a key pair with a secret exponent in [1, q)
+func GenerateKey(group Group) (*PrivateKey, error) {
+	x, err := rand.Int(rand.Reader, new(big.Int).Sub(group.Q, big.NewInt(1)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret exponent: %v", err)
+	}
+	return NewPrivateKey(group, x.Add(x, big.NewInt(1))), nil
+}
+
+// NewPrivateKey returns the key pair for the given secret exponent
+func NewPrivateKey(group Group, x *big.Int) *PrivateKey {
+	return &PrivateKey{
+		Group: group,
+		X:     new(big.Int).Set(x),
+		Y:     new(big.Int).Exp(group.G, x, group.P),
+	}
+}
+
+// SharedSecret computes the shared secret peer^x mod p. The peer's public
+// key is used as is, without checking that it lies in the right subgroup.
+func (k *PrivateKey) SharedSecret(peer *big.Int) *big.Int {
+	return new(big.Int).Exp(peer, k.X, k.P)
+}
+
+// Cofactor returns j = (p-1)/q
+func (group Group) Cofactor() *big.Int {
+	return new(big.Int).Quo(new(big.Int).Sub(group.P, big.NewInt(1)), group.Q)
+}
@@ -0,0 +1,37 @@
+package dh
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	for _, group := range []Group{Set8Group, KangarooGroup} {
+		if !group.P.ProbablyPrime(20) || !group.Q.ProbablyPrime(20) {
+			t.Fatalf("p and q must be prime")
+		}
+		pm1 := new(big.Int).Sub(group.P, big.NewInt(1))
+		if new(big.Int).Mul(group.Cofactor(), group.Q).Cmp(pm1) != 0 {
+			t.Errorf("q does not divide p-1")
+		}
+		if new(big.Int).Exp(group.G, group.Q, group.P).Cmp(big.NewInt(1)) != 0 {
+			t.Errorf("g does not have order q")
+		}
+	}
+}
+
+func TestSharedSecret(t *testing.T) {
+	alice, err := GenerateKey(Set8Group)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	bob, err := GenerateKey(Set8Group)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	a := alice.SharedSecret(bob.Y)
+	b := bob.SharedSecret(alice.Y)
+	if a.Cmp(b) != 0 {
+		t.Errorf("Shared secrets differ: \nAlice: %v \nBob: %v", a, b)
+	}
+}
@@ -0,0 +1,116 @@
+package dh
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// kangarooAttempts is the number of walks Kangaroo makes before giving up.
+// The wild kangaroo misses the trap with a small but constant probability.
+const kangarooAttempts = 8
+
+// Kangaroo finds the discrete log x of y to the base g mod p, where x is
+// known to lie in [a, b], using Pollard's kangaroo (lambda) method. The jump
+// function is chosen based on the size of the interval.
+func Kangaroo(g, y, p, a, b *big.Int) (*big.Int, error) {
+	// The mean jump 2^k/k should be around sqrt(b-a)/2, which gives
+	// k ≈ log2(sqrt(b-a)) + log2(log2(sqrt(b-a))) - 1
+	half := (new(big.Int).Sub(b, a).BitLen() + 1) / 2
+	k := half - 1
+	for l := half; l > 1; l >>= 1 {
+		k++
+	}
+	if k < 1 {
+		k = 1
+	}
+	if k > 62 {
+		k = 62
+	}
+	return kangarooRetry(g, y, p, a, b, k, kangarooAttempts)
+}
+
+// kangarooRetry makes up to attempts walks with jump parameter k, each
+// with the next shift
+func kangarooRetry(g, y, p, a, b *big.Int, k, attempts int) (*big.Int, error) {
+	var err error
+	for s := 0; s < attempts; s++ {
+		var x *big.Int
+		if x, err = kangarooShifted(g, y, p, a, b, k, int64(s)); err == nil {
+			return x, nil
+		}
+	}
+	return nil, fmt.Errorf("%v after %v attempts", err, attempts)
+}
+
+// kangarooShifted finds x by looking for x+s in [a+s, b+s] instead, which
+// sends both kangaroos on different walks than looking for x itself
+func kangarooShifted(g, y, p, a, b *big.Int, k int, s int64) (*big.Int, error) {
+	shift := big.NewInt(s)
+	ys := new(big.Int).Exp(g, shift, p)
+	ys.Mul(ys, y)
+	ys.Mod(ys, p)
+	x, err := KangarooWithJumps(g, ys, p, new(big.Int).Add(a, shift), new(big.Int).Add(b, shift), k)
+	if err != nil {
+		return nil, err
+	}
+	return x.Sub(x, shift), nil
+}
+
+// KangarooWithJumps is like Kangaroo, but with a tunable jump function
+// f(y) = 2^(y mod k), with k at most 62.
+//
+// A tame kangaroo starts at g^b and makes N jumps, where N is four times the
+// mean jump, leaving a trap. A wild kangaroo starts at y and jumps until it
+// either lands in the trap, revealing x, or passes it. This is a single
+// attempt, which can fail even if x is in [a, b].
+func KangarooWithJumps(g, y, p, a, b *big.Int, k int) (*big.Int, error) {
+	if k < 1 || k > 62 {
+		return nil, fmt.Errorf("jump parameter k must be in [1, 62], got %v", k)
+	}
+	if b.Cmp(a) < 0 {
+		return nil, fmt.Errorf("empty interval [%v, %v]", a, b)
+	}
+
+	// Precompute the jump sizes 2^i and the corresponding g^(2^i)
+	bigK := big.NewInt(int64(k))
+	jumps := make([]*big.Int, k)
+	for i := range jumps {
+		jumps[i] = new(big.Int).Exp(g, new(big.Int).Lsh(big.NewInt(1), uint(i)), p)
+	}
+	f := func(y *big.Int) int {
+		return int(new(big.Int).Mod(y, bigK).Int64())
+	}
+	mean := (int64(1)<<uint(k) - 1) / int64(k)
+	n := 4 * mean
+	if n < 1 {
+		n = 1
+	}
+
+	// Tame kangaroo
+	xT := new(big.Int)
+	yT := new(big.Int).Exp(g, b, p)
+	for i := int64(0); i < n; i++ {
+		j := f(yT)
+		xT.Add(xT, new(big.Int).Lsh(big.NewInt(1), uint(j)))
+		yT.Mul(yT, jumps[j])
+		yT.Mod(yT, p)
+	}
+
+	// Wild kangaroo
+	xW := new(big.Int)
+	yW := new(big.Int).Set(y)
+	limit := new(big.Int).Sub(b, a)
+	limit.Add(limit, xT)
+	for xW.Cmp(limit) <= 0 {
+		if yW.Cmp(yT) == 0 {
+			// b + xT = x + xW
+			x := new(big.Int).Add(b, xT)
+			return x.Sub(x, xW), nil
+		}
+		j := f(yW)
+		xW.Add(xW, new(big.Int).Lsh(big.NewInt(1), uint(j)))
+		yW.Mul(yW, jumps[j])
+		yW.Mod(yW, p)
+	}
+	return nil, fmt.Errorf("wild kangaroo escaped: no log found in [%v, %v]", a, b)
+}
@@ -0,0 +1,91 @@
+package dh
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestKangaroo(t *testing.T) {
+	group := KangarooGroup
+	rng := rand.New(rand.NewSource(58))
+	ex := []struct {
+		a, b int64
+	}{
+		{0, 1 << 10},
+		{0, 1 << 20},
+		{1 << 30, 1<<30 + 1<<24},
+		{12345, 12345},
+	}
+	for _, e := range ex {
+		x := big.NewInt(e.a + rng.Int63n(e.b-e.a+1))
+		y := new(big.Int).Exp(group.G, x, group.P)
+		result, err := Kangaroo(group.G, y, group.P, big.NewInt(e.a), big.NewInt(e.b))
+		if err != nil {
+			t.Errorf("Kangaroo in [%v, %v] failed: %v", e.a, e.b, err)
+			continue
+		}
+		if result.Cmp(x) != 0 {
+			t.Errorf("Kangaroo in [%v, %v] failed: \nExp: %v \nGot: %v", e.a, e.b, x, result)
+		}
+	}
+}
+
+func TestKangarooShifted(t *testing.T) {
+	group := KangarooGroup
+	x := big.NewInt(54321)
+	y := new(big.Int).Exp(group.G, x, group.P)
+	for _, s := range []int64{0, 1, 7, 1 << 20} {
+		result, err := kangarooShifted(group.G, y, group.P, big.NewInt(1<<15), big.NewInt(1<<16), 9, s)
+		if err != nil {
+			t.Errorf("Kangaroo shifted by %v failed: %v", s, err)
+			continue
+		}
+		if result.Cmp(x) != 0 {
+			t.Errorf("Kangaroo shifted by %v failed: \nExp: %v \nGot: %v", s, x, result)
+		}
+	}
+}
+
+func TestKangarooChallenge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping 40 bit kangaroo in short mode")
+	}
+	group := KangarooGroup
+	y := fromDecimal("9388897478013399550694114614498790691034187453089355259602614074132918843899833277397448144245883225611726912025846772975325932794909655215329941809013733")
+	expected := big.NewInt(359579674340)
+	x, err := Kangaroo(group.G, y, group.P, big.NewInt(0), big.NewInt(1<<40))
+	if err != nil {
+		t.Fatalf("Kangaroo failed: %v", err)
+	}
+	if x.Cmp(expected) != 0 {
+		t.Errorf("Kangaroo failed: \nExp: %v \nGot: %v", expected, x)
+	}
+}
+
+func TestKangarooOutOfRange(t *testing.T) {
+	group := KangarooGroup
+	y := new(big.Int).Exp(group.G, big.NewInt(1<<20), group.P)
+	if _, err := Kangaroo(group.G, y, group.P, big.NewInt(0), big.NewInt(1<<10)); err == nil {
+		t.Errorf("Kangaroo found a log outside the interval")
+	}
+}
+
+// The running time should grow with the square root of the interval size,
+// so each step below should take about four times as long as the last
+func BenchmarkKangaroo(b *testing.B) {
+	group := KangarooGroup
+	rng := rand.New(rand.NewSource(58))
+	for _, bits := range []uint{16, 20, 24, 28} {
+		b.Run(fmt.Sprintf("%vbits", bits), func(b *testing.B) {
+			upper := big.NewInt(1 << bits)
+			for i := 0; i < b.N; i++ {
+				y := new(big.Int).Exp(group.G, big.NewInt(rng.Int63n(1<<bits)), group.P)
+				if _, err := Kangaroo(group.G, y, group.P, big.NewInt(0), upper); err != nil {
+					b.Fatalf("Kangaroo failed: %v", err)
+				}
+			}
+		})
+	}
+}
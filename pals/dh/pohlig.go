@@ -0,0 +1,85 @@
+package dh
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// PohligHellman finds the discrete log x of y to the base g mod p, given
+// the prime factorization of p-1 with repeated factors listed repeatedly.
+// g must generate the whole multiplicative group, and the factors must be
+// small, since the log in each subgroup of prime order is brute forced.
+func PohligHellman(g, y, p *big.Int, factors []*big.Int) (*big.Int, error) {
+	pm1 := new(big.Int).Sub(p, bigOne)
+	product := big.NewInt(1)
+	exponents := make(map[string]int)
+	var primes []*big.Int
+	for _, f := range factors {
+		if exponents[f.String()] == 0 {
+			primes = append(primes, f)
+		}
+		exponents[f.String()]++
+		product.Mul(product, f)
+	}
+	if product.Cmp(pm1) != 0 {
+		return nil, fmt.Errorf("factors do not multiply to p-1")
+	}
+
+	var residues, moduli []*big.Int
+	for _, q := range primes {
+		e := exponents[q.String()]
+		qe := new(big.Int).Exp(q, big.NewInt(int64(e)), nil)
+		x, err := primePowerLog(g, y, p, q, e, qe)
+		if err != nil {
+			return nil, err
+		}
+		residues = append(residues, x)
+		moduli = append(moduli, qe)
+	}
+	x, _, err := rsa.CRT(residues, moduli)
+	return x, err
+}
+
+var bigOne = big.NewInt(1)
+
+// primePowerLog finds x mod q^e, one base q digit at a time
+func primePowerLog(g, y, p, q *big.Int, e int, qe *big.Int) (*big.Int, error) {
+	cofactor := new(big.Int).Sub(p, bigOne)
+	cofactor.Quo(cofactor, qe)
+	gi := new(big.Int).Exp(g, cofactor, p)
+	yi := new(big.Int).Exp(y, cofactor, p)
+	giInv := new(big.Int).ModInverse(gi, p)
+
+	// gamma has order q, so each digit is its log of something
+	qe1 := new(big.Int).Quo(qe, q)
+	gamma := new(big.Int).Exp(gi, qe1, p)
+
+	x := new(big.Int)
+	qk := big.NewInt(1)
+	for k := 0; k < e; k++ {
+		// h = (g_i^-x * y_i)^(q^(e-1-k)) = gamma^d_k
+		h := new(big.Int).Exp(giInv, x, p)
+		h.Mul(h, yi)
+		h.Mod(h, p)
+		h.Exp(h, new(big.Int).Quo(qe1, qk), p)
+
+		d, found := int64(0), false
+		cur := big.NewInt(1)
+		for ; d < q.Int64(); d++ {
+			if cur.Cmp(h) == 0 {
+				found = true
+				break
+			}
+			cur.Mul(cur, gamma)
+			cur.Mod(cur, p)
+		}
+		if !found {
+			return nil, fmt.Errorf("no log mod %v found; g might not be a generator", q)
+		}
+		x.Add(x, new(big.Int).Mul(big.NewInt(d), qk))
+		qk.Mul(qk, q)
+	}
+	return x, nil
+}
@@ -0,0 +1,42 @@
+package dh
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPohligHellman(t *testing.T) {
+	ex := []struct {
+		g, p    int64
+		factors []int64
+		x       int64
+	}{
+		// 2 generates the group mod 101, p-1 = 2^2 * 5^2
+		{2, 101, []int64{2, 2, 5, 5}, 37},
+		{2, 101, []int64{2, 2, 5, 5}, 0},
+		// 3 generates the group mod 65537, p-1 = 2^16
+		{3, 65537, []int64{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}, 54321},
+		// 2 generates the group mod 1000003, p-1 = 2 * 3 * 166667
+		{2, 1000003, []int64{2, 3, 166667}, 999999},
+	}
+	for _, e := range ex {
+		g, p := big.NewInt(e.g), big.NewInt(e.p)
+		var factors []*big.Int
+		for _, f := range e.factors {
+			factors = append(factors, big.NewInt(f))
+		}
+		y := new(big.Int).Exp(g, big.NewInt(e.x), p)
+		x, err := PohligHellman(g, y, p, factors)
+		if err != nil {
+			t.Errorf("PohligHellman(%v, %v, %v) failed: %v", e.g, y, e.p, err)
+			continue
+		}
+		if new(big.Int).Exp(g, x, p).Cmp(y) != 0 || x.Int64() != e.x {
+			t.Errorf("PohligHellman(%v, %v, %v) failed: \nExp: %v \nGot: %v", e.g, y, e.p, e.x, x)
+		}
+	}
+
+	if _, err := PohligHellman(big.NewInt(2), big.NewInt(3), big.NewInt(101), []*big.Int{big.NewInt(2), big.NewInt(5)}); err == nil {
+		t.Errorf("PohligHellman accepted an incomplete factorization")
+	}
+}
@@ -0,0 +1,185 @@
+// Package dsa implements the Digital Signature Algorithm as described in
+// FIPS 186. Unlike crypto/dsa it exposes the signing nonce, which makes
+// nonce based attacks straightforward to demonstrate.
+package dsa
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// Parameters represents the domain parameters of a DSA key
+type Parameters struct {
+	P, Q, G *big.Int
+}
+
+// PublicKey represents a DSA public key
+type PublicKey struct {
+	Parameters
+	Y *big.Int
+}
+
+// PrivateKey represents a DSA private key
+type PrivateKey struct {
+	PublicKey
+	X *big.Int
+}
+
+// DefaultParameters are the domain parameters used by the cryptopals challenges
+var DefaultParameters = Parameters{
+	P: fromHex("800000000000000089e1855218a0e7dac38136ffafa72eda7" +
+		"859f2171e25e65eac698c1702578b07dc2a1076da241c76c6" +
+		"2d374d8389ea5aeffd3226a0530cc565f3bf6b50929139ebe" +
+		"ac04f48c3c84afb796d61e5a4f9a8fda812ab59494232c7d2" +
+		"b4deb50aa18ee9e132bfa85ac4374d7f9091abc3d015efc87" +
+		"1a584471bb1"),
+	Q: fromHex("f4f47f05794b256174bba6e9b396a7707e563c5b"),
+	G: fromHex("5958c9d3898b224b12672c0b98e06c60df923cb8bc999d119" +
+		"458fef538b8fa4046c8db53039db620c094c9fa077ef389b5" +
+		"322a559946a71903f990f1f7e0e025e2d7f7cf494aff1a047" +
+		"0f5b64c36b625a097f1651fe775323556fe00b3608c887892" +
+		"878480e99041be601a62166ca6894bdd41a7054ec89f756ba" +
+		"9fc95302291"),
+}
+
+func fromHex(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("dsa: invalid hex constant " + s)
+	}
+	return n
+}
+
+// GenerateKey generates a DSA key pair for the given parameters
+func GenerateKey(params Parameters) (*PrivateKey, error) {
+	x, err := randomScalar(params.Q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %v", err)
+	}
+	return &PrivateKey{
+		PublicKey: PublicKey{
+			Parameters: params,
+			Y:          new(big.Int).Exp(params.G, x, params.P),
+		},
+		X: x,
+	}, nil
+}
+
+// Sign signs the given digest with a random nonce, returning the
+// signature (r, s)
+func Sign(priv *PrivateKey, hashed []byte) (r, s *big.Int, err error) {
+	for {
+		k, err := randomScalar(priv.Q)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate nonce: %v", err)
+		}
+		r, s, err = SignWithNonce(priv, hashed, k)
+		if err == nil {
+			return r, s, nil
+		}
+	}
+}
+
+// SignWithNonce signs the given digest using the nonce k. Reusing or
+// leaking k reveals the private key.
+// An error is returned if k yields r = 0 or s = 0, in which case
+// a different k must be chosen.
+func SignWithNonce(priv *PrivateKey, hashed []byte, k *big.Int) (r, s *big.Int, err error) {
+	kInv := new(big.Int).ModInverse(k, priv.Q)
+	if kInv == nil {
+		return nil, nil, fmt.Errorf("nonce %v not invertible mod q", k)
+	}
+	r = new(big.Int).Exp(priv.G, k, priv.P)
+	r.Mod(r, priv.Q)
+	if r.Sign() == 0 {
+		return nil, nil, fmt.Errorf("nonce %v yields r = 0", k)
+	}
+
+	// s = k^-1 (H(m) + x*r) mod q
+	s = new(big.Int).Mul(priv.X, r)
+	s.Add(s, HashToInt(hashed, priv.Q))
+	s.Mul(s, kInv)
+	s.Mod(s, priv.Q)
+	if s.Sign() == 0 {
+		return nil, nil, fmt.Errorf("nonce %v yields s = 0", k)
+	}
+	return r, s, nil
+}
+
+// WithGenerator returns a copy of the parameters with the generator
+// replaced by g
+func (params Parameters) WithGenerator(g *big.Int) Parameters {
+	params.G = new(big.Int).Set(g)
+	return params
+}
+
+// Validate checks that the generator is a proper element of order q in
+// the multiplicative group mod p
+func (params Parameters) Validate() error {
+	if params.G.Cmp(big.NewInt(1)) <= 0 || params.G.Cmp(params.P) >= 0 {
+		return fmt.Errorf("generator %v out of range (1, p)", params.G)
+	}
+	if new(big.Int).Exp(params.G, params.Q, params.P).Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("generator %v does not have order q", params.G)
+	}
+	return nil
+}
+
+// Verify reports whether (r, s) is a valid signature of the given digest.
+// Signatures are rejected if r or s are out of range or if the key's
+// parameters are invalid.
+func Verify(pub *PublicKey, hashed []byte, r, s *big.Int) bool {
+	if pub.Validate() != nil {
+		return false
+	}
+	if r.Sign() <= 0 || r.Cmp(pub.Q) >= 0 || s.Sign() <= 0 || s.Cmp(pub.Q) >= 0 {
+		return false
+	}
+	return VerifyUnsafe(pub, hashed, r, s)
+}
+
+// VerifyUnsafe is like Verify but skips the range and parameter checks,
+// trusting whatever generator it is handed.
+func VerifyUnsafe(pub *PublicKey, hashed []byte, r, s *big.Int) bool {
+	w := new(big.Int).ModInverse(s, pub.Q)
+	if w == nil {
+		return false
+	}
+	u1 := HashToInt(hashed, pub.Q)
+	u1.Mul(u1, w)
+	u1.Mod(u1, pub.Q)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, pub.Q)
+
+	// v = (g^u1 * y^u2 mod p) mod q
+	v := new(big.Int).Exp(pub.G, u1, pub.P)
+	v.Mul(v, new(big.Int).Exp(pub.Y, u2, pub.P))
+	v.Mod(v, pub.P)
+	v.Mod(v, pub.Q)
+	return v.Cmp(r) == 0
+}
+
+// HashToInt converts a digest into an integer, keeping only the leftmost
+// bits if the digest is longer than q as per FIPS 186-4 §4.6
+func HashToInt(hashed []byte, q *big.Int) *big.Int {
+	orderBytes := (q.BitLen() + 7) / 8
+	if len(hashed) > orderBytes {
+		hashed = hashed[:orderBytes]
+	}
+	z := new(big.Int).SetBytes(hashed)
+	if excess := len(hashed)*8 - q.BitLen(); excess > 0 {
+		z.Rsh(z, uint(excess))
+	}
+	return z
+}
+
+// randomScalar returns a random integer in [1, q)
+func randomScalar(q *big.Int) (*big.Int, error) {
+	max := new(big.Int).Sub(q, big.NewInt(1))
+	k, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return nil, err
+	}
+	return k.Add(k, big.NewInt(1)), nil
+}
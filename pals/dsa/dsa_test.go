@@ -0,0 +1,79 @@
+package dsa
+
+import (
+	"crypto/sha1"
+	"math/big"
+	"testing"
+)
+
+func TestDefaultParameters(t *testing.T) {
+	params := DefaultParameters
+	if !params.P.ProbablyPrime(20) || !params.Q.ProbablyPrime(20) {
+		t.Fatalf("p and q must be prime")
+	}
+	pm1 := new(big.Int).Sub(params.P, big.NewInt(1))
+	if new(big.Int).Mod(pm1, params.Q).Sign() != 0 {
+		t.Errorf("q does not divide p-1")
+	}
+	if new(big.Int).Exp(params.G, params.Q, params.P).Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("g does not have order q")
+	}
+}
+
+func TestSignVerifyDSA(t *testing.T) {
+	ex := []string{
+		"hi mom",
+		"For those that envy a MC it can be hazardous to your health",
+	}
+
+	priv, err := GenerateKey(DefaultParameters)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	for _, e := range ex {
+		hashed := sha1.Sum([]byte(e))
+		r, s, err := Sign(priv, hashed[:])
+		if err != nil {
+			t.Fatalf("failed to sign %q: %v", e, err)
+		}
+		if !Verify(&priv.PublicKey, hashed[:], r, s) {
+			t.Errorf("Verification of valid signature for %q failed", e)
+		}
+
+		other := sha1.Sum([]byte(e + "!"))
+		if Verify(&priv.PublicKey, other[:], r, s) {
+			t.Errorf("Signature for %q verified for a different message", e)
+		}
+		if Verify(&priv.PublicKey, hashed[:], r, new(big.Int).Add(s, big.NewInt(1))) {
+			t.Errorf("Tampered signature for %q verified", e)
+		}
+	}
+}
+
+func TestValidateParameters(t *testing.T) {
+	p := DefaultParameters.P
+	ex := []struct {
+		g     *big.Int
+		valid bool
+	}{
+		{DefaultParameters.G, true},
+		{big.NewInt(0), false},
+		{big.NewInt(1), false},
+		{new(big.Int).Add(p, big.NewInt(1)), false},
+		{big.NewInt(2), false}, // 2 doesn't generate the order q subgroup
+	}
+	for _, e := range ex {
+		params := DefaultParameters.WithGenerator(e.g)
+		if err := params.Validate(); (err == nil) != e.valid {
+			t.Errorf("Validate with g = %v failed: Expected valid: %v Got: %v", e.g, e.valid, err)
+		}
+	}
+	if DefaultParameters.G.Cmp(fromHex("5958c9d3898b224b12672c0b98e06c60df923cb8bc999d119"+
+		"458fef538b8fa4046c8db53039db620c094c9fa077ef389b5"+
+		"322a559946a71903f990f1f7e0e025e2d7f7cf494aff1a047"+
+		"0f5b64c36b625a097f1651fe775323556fe00b3608c887892"+
+		"878480e99041be601a62166ca6894bdd41a7054ec89f756ba"+
+		"9fc95302291")) != 0 {
+		t.Errorf("WithGenerator modified the original parameters")
+	}
+}
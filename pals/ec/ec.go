@@ -0,0 +1,245 @@
+// Package ec implements arithmetic on short Weierstrass elliptic curves
+// y^2 = x^3 + ax + b over GF(p) and elliptic curve Diffie-Hellman.
+// Unlike crypto/elliptic, nothing checks that points lie on the curve they
+// are used with, so that invalid curve attacks can be demonstrated.
+package ec
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// Curve represents the curve y^2 = x^3 + ax + b over GF(p), with a base
+// point G of prime order N
+type Curve struct {
+	P, A, B *big.Int
+	G       Point
+	N       *big.Int
+}
+
+// Point is an affine point on a curve. The zero value is the point at
+// infinity, which is the identity of the group.
+type Point struct {
+	X, Y *big.Int
+}
+
+// Infinity is the point at infinity
+var Infinity = Point{}
+
+// Set8Curve is the curve used by the cryptopals set 8 challenges.
+// Its order is 8*N.
+var Set8Curve = Curve{
+	P: FromDecimal("233970423115425145524320034830162017933"),
+	A: big.NewInt(-95051),
+	B: big.NewInt(11279326),
+	G: Point{
+		X: big.NewInt(182),
+		Y: FromDecimal("85518893674295321206118380980485522083"),
+	},
+	N: FromDecimal("29246302889428143187362802287225875743"),
+}
+
+// FromDecimal parses a decimal constant, panicking if it is malformed
+func FromDecimal(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("ec: invalid decimal constant " + s)
+	}
+	return n
+}
+
+// NewPoint returns the point (x, y)
+func NewPoint(x, y int64) Point {
+	return Point{X: big.NewInt(x), Y: big.NewInt(y)}
+}
+
+// IsInfinity reports whether p is the point at infinity
+func (p Point) IsInfinity() bool {
+	return p.X == nil
+}
+
+// Equal reports whether p and q are the same point
+func (p Point) Equal(q Point) bool {
+	if p.IsInfinity() || q.IsInfinity() {
+		return p.IsInfinity() == q.IsInfinity()
+	}
+	return p.X.Cmp(q.X) == 0 && p.Y.Cmp(q.Y) == 0
+}
+
+func (p Point) String() string {
+	if p.IsInfinity() {
+		return "O"
+	}
+	return fmt.Sprintf("(%v, %v)", p.X, p.Y)
+}
+
+// IsOnCurve reports whether p satisfies the curve equation
+func (c Curve) IsOnCurve(p Point) bool {
+	if p.IsInfinity() {
+		return true
+	}
+	return c.rhs(p.X).Cmp(c.mod(new(big.Int).Mul(p.Y, p.Y))) == 0
+}
+
+// rhs computes x^3 + ax + b mod p
+func (c Curve) rhs(x *big.Int) *big.Int {
+	y2 := new(big.Int).Mul(x, x)
+	y2.Add(y2, c.A)
+	y2.Mul(y2, x)
+	y2.Add(y2, c.B)
+	return c.mod(y2)
+}
+
+func (c Curve) mod(x *big.Int) *big.Int {
+	return x.Mod(x, c.P)
+}
+
+// Neg returns -p
+func (c Curve) Neg(p Point) Point {
+	if p.IsInfinity() {
+		return Infinity
+	}
+	return Point{X: new(big.Int).Set(p.X), Y: c.mod(new(big.Int).Neg(p.Y))}
+}
+
+// reduce returns p with its coordinates reduced mod the curve's prime.
+// Points from a peer may not be.
+func (c Curve) reduce(p Point) Point {
+	if p.X.Sign() >= 0 && p.X.Cmp(c.P) < 0 && p.Y.Sign() >= 0 && p.Y.Cmp(c.P) < 0 {
+		return p
+	}
+	return Point{X: c.mod(new(big.Int).Set(p.X)), Y: c.mod(new(big.Int).Set(p.Y))}
+}
+
+// Add returns p + q. The curve's b is never used, so this works for points
+// on any curve sharing p and a.
+func (c Curve) Add(p, q Point) Point {
+	if p.IsInfinity() {
+		return q
+	}
+	if q.IsInfinity() {
+		return p
+	}
+	p, q = c.reduce(p), c.reduce(q)
+	// Slope of the line through p and q, or of the tangent if they're equal
+	var num, den *big.Int
+	if p.X.Cmp(q.X) == 0 {
+		if c.mod(new(big.Int).Add(p.Y, q.Y)).Sign() == 0 {
+			return Infinity
+		}
+		num = new(big.Int).Mul(p.X, p.X)
+		num.Mul(num, big.NewInt(3))
+		num.Add(num, c.A)
+		den = new(big.Int).Lsh(p.Y, 1)
+	} else {
+		num = new(big.Int).Sub(q.Y, p.Y)
+		den = new(big.Int).Sub(q.X, p.X)
+	}
+	m := den.ModInverse(den, c.P)
+	if m == nil {
+		// Vertical line
+		return Infinity
+	}
+	m.Mul(m, num)
+	c.mod(m)
+
+	x := num.Mul(m, m)
+	x.Sub(x, p.X)
+	x.Sub(x, q.X)
+	c.mod(x)
+	y := new(big.Int).Sub(p.X, x)
+	y.Mul(y, m)
+	y.Sub(y, p.Y)
+	return Point{X: x, Y: c.mod(y)}
+}
+
+// Double returns 2p
+func (c Curve) Double(p Point) Point {
+	return c.Add(p, p)
+}
+
+// ScalarMult returns k*p using double-and-add
+func (c Curve) ScalarMult(p Point, k *big.Int) Point {
+	if k.Sign() < 0 {
+		return c.ScalarMult(c.Neg(p), new(big.Int).Neg(k))
+	}
+	r := Infinity
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = c.Double(r)
+		if k.Bit(i) == 1 {
+			r = c.Add(r, p)
+		}
+	}
+	return r
+}
+
+// ScalarBaseMult returns k*G
+func (c Curve) ScalarBaseMult(k *big.Int) Point {
+	return c.ScalarMult(c.G, k)
+}
+
+// WithB returns the curve with the same p and a but a different b. Its
+// base point and order are left unset.
+func (c Curve) WithB(b *big.Int) Curve {
+	return Curve{P: c.P, A: c.A, B: new(big.Int).Set(b)}
+}
+
+// RandomPoint returns a uniformly random point on the curve, other than
+// the point at infinity
+func (c Curve) RandomPoint(rand io.Reader) (Point, error) {
+	for {
+		x, err := crand.Int(rand, c.P)
+		if err != nil {
+			return Infinity, fmt.Errorf("failed to generate x coordinate: %v", err)
+		}
+		y := new(big.Int).ModSqrt(c.rhs(x), c.P)
+		if y == nil {
+			continue
+		}
+		b, err := crand.Int(rand, big.NewInt(2))
+		if err != nil {
+			return Infinity, fmt.Errorf("failed to choose y coordinate: %v", err)
+		}
+		if b.Sign() == 1 {
+			c.mod(y.Neg(y))
+		}
+		return Point{X: x, Y: y}, nil
+	}
+}
+
+// PointOfOrder returns a random point of prime order r on the curve, given
+// the order of the curve's group, which r must divide
+func (c Curve) PointOfOrder(rand io.Reader, order, r *big.Int) (Point, error) {
+	cofactor, m := new(big.Int).QuoRem(order, r, new(big.Int))
+	if m.Sign() != 0 {
+		return Infinity, fmt.Errorf("%v does not divide the group order", r)
+	}
+	for {
+		p, err := c.RandomPoint(rand)
+		if err != nil {
+			return Infinity, err
+		}
+		if h := c.ScalarMult(p, cofactor); !h.IsInfinity() {
+			return h, nil
+		}
+	}
+}
+
+// PointOrder returns the order of p, given a multiple n of it (such as the
+// group order) and the complete prime factorization of n
+func (c Curve) PointOrder(p Point, n *big.Int, factors []*big.Int) *big.Int {
+	order := new(big.Int).Set(n)
+	m := new(big.Int)
+	for _, f := range factors {
+		for new(big.Int).Mod(order, f).Sign() == 0 {
+			m.Quo(order, f)
+			if !c.ScalarMult(p, m).IsInfinity() {
+				break
+			}
+			order.Set(m)
+		}
+	}
+	return order
+}
@@ -0,0 +1,169 @@
+package ec
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// y^2 = x^3 + 2x + 2 over GF(17), generated by (5, 1) of order 19
+var toyCurve = Curve{
+	P: big.NewInt(17),
+	A: big.NewInt(2),
+	B: big.NewInt(2),
+	G: NewPoint(5, 1),
+	N: big.NewInt(19),
+}
+
+func TestSet8Curve(t *testing.T) {
+	c := Set8Curve
+	if !c.P.ProbablyPrime(20) || !c.N.ProbablyPrime(20) {
+		t.Fatalf("p and n must be prime")
+	}
+	if !c.IsOnCurve(c.G) {
+		t.Fatalf("base point is not on the curve")
+	}
+	if !c.ScalarBaseMult(c.N).IsInfinity() {
+		t.Errorf("base point does not have order n")
+	}
+}
+
+func TestScalarMult(t *testing.T) {
+	ex := []struct {
+		k        int64
+		expected Point
+	}{
+		{0, Infinity},
+		{1, NewPoint(5, 1)},
+		{2, NewPoint(6, 3)},
+		{3, NewPoint(10, 6)},
+		{9, NewPoint(7, 6)},
+		{18, NewPoint(5, 16)},
+		{19, Infinity},
+		{20, NewPoint(5, 1)},
+		{-1, NewPoint(5, 16)},
+	}
+	for _, e := range ex {
+		result := toyCurve.ScalarBaseMult(big.NewInt(e.k))
+		if !result.Equal(e.expected) {
+			t.Errorf("ScalarMult(%v) failed: \nExp: %v \nGot: %v", e.k, e.expected, result)
+		}
+		if !toyCurve.IsOnCurve(result) {
+			t.Errorf("ScalarMult(%v) = %v is not on the curve", e.k, result)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	c := Set8Curve
+	p := c.ScalarBaseMult(big.NewInt(1234567))
+	q := c.ScalarBaseMult(big.NewInt(7654321))
+	r := c.ScalarBaseMult(big.NewInt(42))
+
+	if !c.Add(p, q).Equal(c.Add(q, p)) {
+		t.Errorf("Add is not commutative")
+	}
+	if !c.Add(c.Add(p, q), r).Equal(c.Add(p, c.Add(q, r))) {
+		t.Errorf("Add is not associative")
+	}
+	if !c.Add(p, Infinity).Equal(p) || !c.Add(Infinity, p).Equal(p) {
+		t.Errorf("Infinity is not the identity")
+	}
+	if !c.Add(p, c.Neg(p)).IsInfinity() {
+		t.Errorf("p + -p is not the identity")
+	}
+	if !c.Add(p, q).Equal(c.ScalarBaseMult(big.NewInt(1234567 + 7654321))) {
+		t.Errorf("Add does not agree with ScalarMult")
+	}
+}
+
+func TestAddUnreduced(t *testing.T) {
+	c := Set8Curve
+	p := c.ScalarBaseMult(big.NewInt(1234567))
+	q := c.ScalarBaseMult(big.NewInt(7654321))
+	shift := func(p Point) Point {
+		return Point{X: new(big.Int).Add(p.X, c.P), Y: new(big.Int).Sub(p.Y, c.P)}
+	}
+
+	if !c.Add(p, shift(q)).Equal(c.Add(p, q)) {
+		t.Errorf("Add with unreduced coordinates failed: \nExp: %v \nGot: %v", c.Add(p, q), c.Add(p, shift(q)))
+	}
+	if !c.Add(p, shift(p)).Equal(c.Double(p)) {
+		t.Errorf("Add of p and unreduced p is not 2p")
+	}
+	if !c.Add(p, shift(c.Neg(p))).IsInfinity() {
+		t.Errorf("Add of p and unreduced -p is not the identity")
+	}
+
+	// Peer keys are used as is, so this must not panic
+	alice, err := GenerateKey(c)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	if !alice.SharedSecret(shift(p)).Equal(alice.SharedSecret(p)) {
+		t.Errorf("SharedSecret with unreduced peer key differs")
+	}
+}
+
+func TestSharedSecret(t *testing.T) {
+	alice, err := GenerateKey(Set8Curve)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	bob, err := GenerateKey(Set8Curve)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	a := alice.SharedSecret(bob.Public)
+	b := bob.SharedSecret(alice.Public)
+	if !a.Equal(b) {
+		t.Errorf("Shared secrets differ: \nAlice: %v \nBob: %v", a, b)
+	}
+}
+
+func TestCurveOrders(t *testing.T) {
+	ex := []struct {
+		b     int64
+		order string
+	}{
+		{11279326, "233970423115425145498902418297807005944"},
+		{210, "233970423115425145550826547352470124412"},
+		{504, "233970423115425145544350131142039591210"},
+		{727, "233970423115425145545378039958152057148"},
+	}
+	for _, e := range ex {
+		c := Set8Curve.WithB(big.NewInt(e.b))
+		for i := 0; i < 3; i++ {
+			p, err := c.RandomPoint(rand.Reader)
+			if err != nil {
+				t.Fatalf("failed to generate point: %v", err)
+			}
+			if !c.IsOnCurve(p) {
+				t.Errorf("RandomPoint(b=%v) = %v is not on the curve", e.b, p)
+			}
+			if !c.ScalarMult(p, FromDecimal(e.order)).IsInfinity() {
+				t.Errorf("Order of curve with b=%v does not annihilate %v", e.b, p)
+			}
+		}
+	}
+}
+
+func TestPointOrder(t *testing.T) {
+	c := Set8Curve
+	order := new(big.Int).Lsh(c.N, 3)
+	factors := []*big.Int{big.NewInt(2), c.N}
+
+	if result := c.PointOrder(c.G, order, factors); result.Cmp(c.N) != 0 {
+		t.Errorf("PointOrder(G) failed: \nExp: %v \nGot: %v", c.N, result)
+	}
+	p, err := c.PointOfOrder(rand.Reader, order, big.NewInt(2))
+	if err != nil {
+		t.Fatalf("PointOfOrder failed: %v", err)
+	}
+	if result := c.PointOrder(p, order, factors); result.Int64() != 2 {
+		t.Errorf("PointOfOrder(2) failed: got %v of order %v", p, result)
+	}
+	if _, err := c.PointOfOrder(rand.Reader, order, big.NewInt(3)); err == nil {
+		t.Errorf("PointOfOrder(3) succeeded, but 3 does not divide the order")
+	}
+}
@@ -0,0 +1,38 @@
+package ec
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// PrivateKey represents an ECDH key pair
+type PrivateKey struct {
+	Curve
+	D      *big.Int // secret scalar
+	Public Point    // D*G
+}
+
+// GenerateKey generates a key pair with a secret scalar in [1, N)
+func GenerateKey(curve Curve) (*PrivateKey, error) {
+	d, err := rand.Int(rand.Reader, new(big.Int).Sub(curve.N, big.NewInt(1)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret scalar: %v", err)
+	}
+	return NewPrivateKey(curve, d.Add(d, big.NewInt(1))), nil
+}
+
+// NewPrivateKey returns the key pair for the given secret scalar
+func NewPrivateKey(curve Curve, d *big.Int) *PrivateKey {
+	return &PrivateKey{
+		Curve:  curve,
+		D:      new(big.Int).Set(d),
+		Public: curve.ScalarBaseMult(d),
+	}
+}
+
+// SharedSecret computes the shared point D*peer. The peer's public key is
+// used as is, without checking that it lies on the curve.
+func (k *PrivateKey) SharedSecret(peer Point) Point {
+	return k.ScalarMult(peer, k.D)
+}
@@ -0,0 +1,89 @@
+package ec
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/dsa"
+)
+
+// PublicKey represents an ECDSA public key. The curve's base point and
+// order are part of the key.
+type PublicKey struct {
+	Curve
+	Q Point
+}
+
+// PublicKey returns the public half of the key pair
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{Curve: k.Curve, Q: k.Public}
+}
+
+// Sign signs the given digest with ECDSA using a random nonce, returning the
+// signature (r, s)
+func Sign(priv *PrivateKey, hashed []byte) (r, s *big.Int, err error) {
+	for {
+		k, err := rand.Int(rand.Reader, new(big.Int).Sub(priv.N, bigOne))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate nonce: %v", err)
+		}
+		r, s, err = SignWithNonce(priv, hashed, k.Add(k, bigOne))
+		if err == nil {
+			return r, s, nil
+		}
+	}
+}
+
+// SignWithNonce signs the given digest using the nonce k.
+// An error is returned if k yields r = 0 or s = 0.
+func SignWithNonce(priv *PrivateKey, hashed []byte, k *big.Int) (r, s *big.Int, err error) {
+	kInv := new(big.Int).ModInverse(k, priv.N)
+	if kInv == nil {
+		return nil, nil, fmt.Errorf("nonce %v not invertible mod n", k)
+	}
+	kG := priv.ScalarBaseMult(k)
+	if kG.IsInfinity() {
+		return nil, nil, fmt.Errorf("nonce %v yields r = 0", k)
+	}
+	r = new(big.Int).Mod(kG.X, priv.N)
+	if r.Sign() == 0 {
+		return nil, nil, fmt.Errorf("nonce %v yields r = 0", k)
+	}
+
+	// s = k^-1 (H(m) + d*r) mod n
+	s = new(big.Int).Mul(priv.D, r)
+	s.Add(s, dsa.HashToInt(hashed, priv.N))
+	s.Mul(s, kInv)
+	s.Mod(s, priv.N)
+	if s.Sign() == 0 {
+		return nil, nil, fmt.Errorf("nonce %v yields s = 0", k)
+	}
+	return r, s, nil
+}
+
+// Verify reports whether (r, s) is a valid ECDSA signature of the digest.
+// Like most implementations, it trusts the curve parameters in the key.
+func Verify(pub *PublicKey, hashed []byte, r, s *big.Int) bool {
+	if r.Sign() <= 0 || r.Cmp(pub.N) >= 0 || s.Sign() <= 0 || s.Cmp(pub.N) >= 0 {
+		return false
+	}
+	u1, u2 := VerifyScalars(pub, hashed, r, s)
+	p := pub.Add(pub.ScalarBaseMult(u1), pub.ScalarMult(pub.Q, u2))
+	if p.IsInfinity() {
+		return false
+	}
+	return new(big.Int).Mod(p.X, pub.N).Cmp(r) == 0
+}
+
+// VerifyScalars returns u1 = H(m)/s and u2 = r/s mod n, for which a valid
+// signature satisfies r = (u1*G + u2*Q).X mod n
+func VerifyScalars(pub *PublicKey, hashed []byte, r, s *big.Int) (u1, u2 *big.Int) {
+	w := new(big.Int).ModInverse(s, pub.N)
+	u1 = dsa.HashToInt(hashed, pub.N)
+	u1.Mul(u1, w)
+	u1.Mod(u1, pub.N)
+	u2 = new(big.Int).Mul(r, w)
+	u2.Mod(u2, pub.N)
+	return u1, u2
+}
@@ -0,0 +1,37 @@
+package ec
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+)
+
+func TestSignVerifyECDSA(t *testing.T) {
+	ex := []string{
+		"hi mom",
+		"For those that envy a MC it can be hazardous to your health",
+	}
+
+	priv, err := GenerateKey(Set8Curve)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	for _, e := range ex {
+		hashed := sha256.Sum256([]byte(e))
+		r, s, err := Sign(priv, hashed[:])
+		if err != nil {
+			t.Fatalf("failed to sign %q: %v", e, err)
+		}
+		if !Verify(priv.PublicKey(), hashed[:], r, s) {
+			t.Errorf("Verification of valid signature for %q failed", e)
+		}
+
+		other := sha256.Sum256([]byte(e + "!"))
+		if Verify(priv.PublicKey(), other[:], r, s) {
+			t.Errorf("Signature for %q verified for a different message", e)
+		}
+		if Verify(priv.PublicKey(), hashed[:], r, new(big.Int).Add(s, big.NewInt(1))) {
+			t.Errorf("Tampered signature for %q verified", e)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package ec
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// kangarooAttempts is the number of walks the kangaroo makes before giving
+// up. The wild kangaroo misses the trap with a small but constant
+// probability.
+const kangarooAttempts = 8
+
+// Kangaroo finds the discrete log x of y to the base g, where x is known
+// to lie in [a, b], using Pollard's kangaroo method. It works like
+// dh.Kangaroo, with the jump function based on the x coordinate.
+func (c Curve) Kangaroo(g, y Point, a, b *big.Int) (*big.Int, error) {
+	x, _, err := c.KangarooAny(g, []Point{y}, a, b)
+	return x, err
+}
+
+// KangarooAny is like Kangaroo, but finds the discrete log of whichever of
+// the ys has one in [a, b], and returns its index. All the wild kangaroos
+// share one tame kangaroo, so this is cheaper than trying each y in turn.
+func (c Curve) KangarooAny(g Point, ys []Point, a, b *big.Int) (*big.Int, int, error) {
+	// The mean jump 2^k/k should be around sqrt(b-a)/2
+	half := (new(big.Int).Sub(b, a).BitLen() + 1) / 2
+	k := half - 1
+	for l := half; l > 1; l >>= 1 {
+		k++
+	}
+	if k < 1 {
+		k = 1
+	}
+	if k > 62 {
+		k = 62
+	}
+
+	// Looking for x+s in [a+s, b+s] instead sends the kangaroos on
+	// different walks, so each miss is retried with the next shift
+	var err error
+	for s := int64(0); s < kangarooAttempts; s++ {
+		shift := big.NewInt(s)
+		sg := c.ScalarMult(g, shift)
+		shifted := make([]Point, len(ys))
+		for i, y := range ys {
+			shifted[i] = c.Add(y, sg)
+		}
+		x, i, e := c.kangarooWalk(g, shifted, new(big.Int).Add(a, shift), new(big.Int).Add(b, shift), k)
+		if e == nil {
+			return x.Sub(x, shift), i, nil
+		}
+		err = e
+	}
+	return nil, 0, fmt.Errorf("%v after %v attempts", err, kangarooAttempts)
+}
+
+// KangarooWithJumps is like Kangaroo, but makes a single attempt with a
+// tunable jump function f(y) = 2^((y.X mod 2^64) mod k), with k at most 62.
+// It can fail even if x is in [a, b].
+func (c Curve) KangarooWithJumps(g, y Point, a, b *big.Int, k int) (*big.Int, error) {
+	x, _, err := c.kangarooWalk(g, []Point{y}, a, b, k)
+	return x, err
+}
+
+// kangarooWalk sends out a tame kangaroo from b*g to set a trap, then a
+// wild kangaroo from each of the ys until one lands in it
+func (c Curve) kangarooWalk(g Point, ys []Point, a, b *big.Int, k int) (*big.Int, int, error) {
+	if k < 1 || k > 62 {
+		return nil, 0, fmt.Errorf("jump parameter k must be in [1, 62], got %v", k)
+	}
+	if b.Cmp(a) < 0 {
+		return nil, 0, fmt.Errorf("empty interval [%v, %v]", a, b)
+	}
+
+	// Precompute the jump sizes 2^i and the jumps 2^i * g
+	sizes := make([]*big.Int, k)
+	jumps := make([]Point, k)
+	for i := range jumps {
+		sizes[i] = new(big.Int).Lsh(bigOne, uint(i))
+		if i == 0 {
+			jumps[i] = g
+		} else {
+			jumps[i] = c.Double(jumps[i-1])
+		}
+	}
+	f := func(y Point) int {
+		if y.IsInfinity() {
+			return 0
+		}
+		return int(y.X.Uint64() % uint64(k))
+	}
+	n := 4 * ((int64(1)<<uint(k) - 1) / int64(k))
+	if n < 1 {
+		n = 1
+	}
+
+	// Tame kangaroo, starting at b*g
+	xT := new(big.Int)
+	yT := c.ScalarMult(g, b)
+	for i := int64(0); i < n; i++ {
+		j := f(yT)
+		xT.Add(xT, sizes[j])
+		yT = c.Add(yT, jumps[j])
+	}
+
+	// Wild kangaroos, starting at each y
+	limit := new(big.Int).Sub(b, a)
+	limit.Add(limit, xT)
+	for i, y := range ys {
+		xW := new(big.Int)
+		yW := y
+		for xW.Cmp(limit) <= 0 {
+			if yW.Equal(yT) {
+				x := new(big.Int).Add(b, xT)
+				return x.Sub(x, xW), i, nil
+			}
+			j := f(yW)
+			xW.Add(xW, sizes[j])
+			yW = c.Add(yW, jumps[j])
+		}
+	}
+	return nil, 0, fmt.Errorf("wild kangaroo escaped: no log found in [%v, %v]", a, b)
+}
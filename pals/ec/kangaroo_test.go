@@ -0,0 +1,51 @@
+package ec
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestKangaroo(t *testing.T) {
+	c := Set8Curve
+	ex := []struct {
+		a, b int64
+	}{
+		{0, 1 << 16},
+		{1 << 30, 1<<30 + 1<<20},
+	}
+	for _, e := range ex {
+		x := big.NewInt(e.a + (e.b-e.a)/3)
+		y := c.ScalarBaseMult(x)
+		result, err := c.Kangaroo(c.G, y, big.NewInt(e.a), big.NewInt(e.b))
+		if err != nil {
+			t.Errorf("Kangaroo in [%v, %v] failed: %v", e.a, e.b, err)
+			continue
+		}
+		if result.Cmp(x) != 0 {
+			t.Errorf("Kangaroo in [%v, %v] failed: \nExp: %v \nGot: %v", e.a, e.b, x, result)
+		}
+	}
+}
+
+func TestKangarooAny(t *testing.T) {
+	c := Set8Curve
+	x := big.NewInt(1<<20 + 12345)
+	y := c.ScalarBaseMult(x)
+	// Only the second target has a log in the interval
+	ys := []Point{c.Neg(y), y}
+	result, i, err := c.KangarooAny(c.G, ys, big.NewInt(1<<20), big.NewInt(1<<21))
+	if err != nil {
+		t.Fatalf("KangarooAny failed: %v", err)
+	}
+	if i != 1 || result.Cmp(x) != 0 {
+		t.Errorf("KangarooAny failed: \nExp: %v at 1 \nGot: %v at %v", x, result, i)
+	}
+}
+
+func TestKangarooOutOfRange(t *testing.T) {
+	c := Set8Curve
+	y := c.ScalarBaseMult(big.NewInt(1 << 20))
+	if _, err := c.Kangaroo(c.G, y, big.NewInt(0), big.NewInt(1<<10)); err == nil {
+		t.Errorf("Kangaroo found a log outside the interval")
+	}
+}
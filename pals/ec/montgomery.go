@@ -0,0 +1,208 @@
+package ec
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// MontgomeryCurve represents the curve Bv^2 = u^3 + Au^2 + u over GF(p),
+// with a base point G of prime order N. Points use X for u and Y for v.
+type MontgomeryCurve struct {
+	P, A, B *big.Int
+	G       Point
+	N       *big.Int
+}
+
+// Set8Montgomery is the Montgomery form of Set8Curve, with u = x - 178
+var Set8Montgomery = MontgomeryCurve{
+	P: Set8Curve.P,
+	A: big.NewInt(534),
+	B: big.NewInt(1),
+	G: Point{
+		X: big.NewInt(4),
+		Y: FromDecimal("85518893674295321206118380980485522083"),
+	},
+	N: Set8Curve.N,
+}
+
+func (m MontgomeryCurve) mod(x *big.Int) *big.Int {
+	return x.Mod(x, m.P)
+}
+
+func (m MontgomeryCurve) inv(x *big.Int) *big.Int {
+	return new(big.Int).ModInverse(m.mod(new(big.Int).Set(x)), m.P)
+}
+
+// rhs computes (u^3 + Au^2 + u) / B mod p
+func (m MontgomeryCurve) rhs(u *big.Int) *big.Int {
+	v2 := new(big.Int).Add(u, m.A)
+	v2.Mul(v2, u)
+	v2.Add(v2, bigOne)
+	v2.Mul(v2, u)
+	v2.Mul(v2, m.inv(m.B))
+	return m.mod(v2)
+}
+
+var bigOne = big.NewInt(1)
+
+// IsOnCurve reports whether some v completes u to a point on the curve.
+// Otherwise u lies on the curve's quadratic twist.
+func (m MontgomeryCurve) IsOnCurve(u *big.Int) bool {
+	return big.Jacobi(m.rhs(u), m.P) >= 0
+}
+
+// Lift returns a point (u, v) on the curve. The other one is (u, -v).
+func (m MontgomeryCurve) Lift(u *big.Int) (Point, error) {
+	v := new(big.Int).ModSqrt(m.rhs(u), m.P)
+	if v == nil {
+		return Infinity, fmt.Errorf("%v is not the u coordinate of a point on the curve", u)
+	}
+	return Point{X: new(big.Int).Set(u), Y: v}, nil
+}
+
+// Weierstrass returns the equivalent short Weierstrass curve, with
+// a = (3 - A^2) / 3B^2 and b = (2A^3 - 9A) / 27B^3
+func (m MontgomeryCurve) Weierstrass() Curve {
+	a := new(big.Int).Mul(m.A, m.A)
+	a.Sub(big.NewInt(3), a)
+	den := new(big.Int).Mul(m.B, m.B)
+	a.Mul(a, m.inv(den.Mul(den, big.NewInt(3))))
+
+	b := new(big.Int).Mul(m.A, m.A)
+	b.Mul(b, big.NewInt(2))
+	b.Sub(b, big.NewInt(9))
+	b.Mul(b, m.A)
+	den = new(big.Int).Exp(m.B, big.NewInt(3), nil)
+	b.Mul(b, m.inv(den.Mul(den, big.NewInt(27))))
+
+	c := Curve{P: m.P, A: m.mod(a), B: m.mod(b), N: m.N}
+	c.G = m.ToWeierstrass(m.G)
+	return c
+}
+
+// ToWeierstrass maps (u, v) to (u/B + A/3B, v/B)
+func (m MontgomeryCurve) ToWeierstrass(p Point) Point {
+	if p.IsInfinity() {
+		return Infinity
+	}
+	bInv := m.inv(m.B)
+	x := new(big.Int).Mul(m.A, m.inv(big.NewInt(3)))
+	x.Add(x, p.X)
+	x.Mul(x, bInv)
+	y := new(big.Int).Mul(p.Y, bInv)
+	return Point{X: m.mod(x), Y: m.mod(y)}
+}
+
+// FromWeierstrass maps (x, y) to (Bx - A/3, By)
+func (m MontgomeryCurve) FromWeierstrass(p Point) Point {
+	if p.IsInfinity() {
+		return Infinity
+	}
+	u := new(big.Int).Mul(m.A, m.inv(big.NewInt(3)))
+	u.Sub(new(big.Int).Mul(m.B, p.X), u)
+	v := new(big.Int).Mul(m.B, p.Y)
+	return Point{X: m.mod(u), Y: m.mod(v)}
+}
+
+// Ladder computes the u coordinate of k*(u, v) with the Montgomery ladder,
+// which never needs v. The point at infinity is returned as 0.
+// Since v is never used, this works just as well for u on the twist.
+func (m MontgomeryCurve) Ladder(u, k *big.Int) *big.Int {
+	u2, w2 := big.NewInt(1), big.NewInt(0)
+	u3, w3 := new(big.Int).Set(u), big.NewInt(1)
+	t1, t2 := new(big.Int), new(big.Int)
+
+	bits := m.P.BitLen()
+	if k.BitLen() > bits {
+		bits = k.BitLen()
+	}
+	for i := bits - 1; i >= 0; i-- {
+		b := k.Bit(i)
+		if b == 1 {
+			u2, u3 = u3, u2
+			w2, w3 = w3, w2
+		}
+		// (u3, w3) = (u2*u3 - w2*w3)^2, u * (u2*w3 - w2*u3)^2
+		t1.Mul(u2, u3)
+		t2.Mul(w2, w3)
+		nu3 := m.mod(new(big.Int).Sub(t1, t2))
+		nu3.Mul(nu3, nu3)
+		t1.Mul(u2, w3)
+		t2.Mul(w2, u3)
+		nw3 := m.mod(new(big.Int).Sub(t1, t2))
+		nw3.Mul(nw3, nw3)
+		nw3.Mul(nw3, u)
+
+		// (u2, w2) = (u2^2 - w2^2)^2, 4*u2*w2 * (u2^2 + A*u2*w2 + w2^2)
+		t1.Mul(u2, u2)
+		t2.Mul(w2, w2)
+		nu2 := m.mod(new(big.Int).Sub(t1, t2))
+		nu2.Mul(nu2, nu2)
+		nw2 := new(big.Int).Mul(u2, w2)
+		nw2.Mul(nw2, m.A)
+		nw2.Add(nw2, t1)
+		nw2.Add(nw2, t2)
+		nw2.Mul(nw2, u2)
+		nw2.Mul(nw2, w2)
+		nw2.Lsh(nw2, 2)
+
+		u2, w2, u3, w3 = m.mod(nu2), m.mod(nw2), m.mod(nu3), m.mod(nw3)
+		if b == 1 {
+			u2, u3 = u3, u2
+			w2, w3 = w3, w2
+		}
+	}
+	if w2.Sign() == 0 {
+		return new(big.Int)
+	}
+	return m.mod(u2.Mul(u2, m.inv(w2)))
+}
+
+// XDouble returns u(2P) = (u^2 - 1)^2 / 4u(u^2 + Au + 1), given u(P).
+// It returns nil if 2P is the point at infinity.
+func (m MontgomeryCurve) XDouble(u *big.Int) *big.Int {
+	den := new(big.Int).Add(u, m.A)
+	den.Mul(den, u)
+	den.Add(den, bigOne)
+	den.Mul(den, u)
+	den.Lsh(den, 2)
+	if m.mod(den).Sign() == 0 {
+		return nil
+	}
+	num := new(big.Int).Mul(u, u)
+	num.Sub(num, bigOne)
+	num.Mul(num, num)
+	return m.mod(num.Mul(num, m.inv(den)))
+}
+
+// XAdd returns u(P+Q) = (uP*uQ - 1)^2 / (uP - uQ)^2 u(P-Q), given u(P),
+// u(Q) and u(P-Q). It returns nil if the result is the point at infinity
+// or P-Q is (0, 0).
+func (m MontgomeryCurve) XAdd(uP, uQ, uDiff *big.Int) *big.Int {
+	den := new(big.Int).Sub(uP, uQ)
+	den.Mul(den, den)
+	den.Mul(den, uDiff)
+	if m.mod(den).Sign() == 0 {
+		return nil
+	}
+	num := new(big.Int).Mul(uP, uQ)
+	num.Sub(num, bigOne)
+	num.Mul(num, num)
+	return m.mod(num.Mul(num, m.inv(den)))
+}
+
+// RandomTwistPoint returns the u coordinate of a uniformly random point on
+// the quadratic twist of the curve, other than (0, 0)
+func (m MontgomeryCurve) RandomTwistPoint(rand io.Reader) (*big.Int, error) {
+	for {
+		u, err := crand.Int(rand, m.P)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate u coordinate: %v", err)
+		}
+		if !m.IsOnCurve(u) {
+			return u, nil
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package ec
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestWeierstrass(t *testing.T) {
+	m := Set8Montgomery
+	c := m.Weierstrass()
+	expA := new(big.Int).Mod(Set8Curve.A, Set8Curve.P)
+	if c.A.Cmp(expA) != 0 || c.B.Cmp(Set8Curve.B) != 0 || !c.G.Equal(Set8Curve.G) {
+		t.Errorf("Weierstrass failed: \nExp: a=%v b=%v G=%v \nGot: a=%v b=%v G=%v",
+			expA, Set8Curve.B, Set8Curve.G, c.A, c.B, c.G)
+	}
+	if !m.FromWeierstrass(c.G).Equal(m.G) {
+		t.Errorf("FromWeierstrass failed: \nExp: %v \nGot: %v", m.G, m.FromWeierstrass(c.G))
+	}
+}
+
+func TestLadder(t *testing.T) {
+	m := Set8Montgomery
+	c := m.Weierstrass()
+	ex := []*big.Int{
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(3),
+		big.NewInt(123456789),
+		new(big.Int).Sub(m.N, big.NewInt(1)),
+	}
+	for _, k := range ex {
+		expected := m.FromWeierstrass(c.ScalarBaseMult(k)).X
+		if result := m.Ladder(m.G.X, k); result.Cmp(expected) != 0 {
+			t.Errorf("Ladder(%v) failed: \nExp: %v \nGot: %v", k, expected, result)
+		}
+	}
+	if result := m.Ladder(m.G.X, m.N); result.Sign() != 0 {
+		t.Errorf("Ladder(N) failed: \nExp: 0 \nGot: %v", result)
+	}
+}
+
+func TestXAdd(t *testing.T) {
+	m := Set8Montgomery
+	u := m.Ladder(m.G.X, big.NewInt(1000))
+	if result := m.XDouble(u); result.Cmp(m.Ladder(m.G.X, big.NewInt(2000))) != 0 {
+		t.Errorf("XDouble failed: got %v", result)
+	}
+	prev, cur := u, m.XDouble(u)
+	for i := int64(3); i < 10; i++ {
+		prev, cur = cur, m.XAdd(cur, u, prev)
+		if expected := m.Ladder(m.G.X, big.NewInt(1000*i)); cur.Cmp(expected) != 0 {
+			t.Errorf("XAdd(%v) failed: \nExp: %v \nGot: %v", i, expected, cur)
+		}
+	}
+}
+
+func TestTwist(t *testing.T) {
+	m := Set8Montgomery
+	// #E + #E' = 2p + 2
+	order := FromDecimal("233970423115425145549737651362517029924")
+	u, err := m.RandomTwistPoint(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate twist point: %v", err)
+	}
+	if m.IsOnCurve(u) {
+		t.Errorf("RandomTwistPoint returned %v on the curve", u)
+	}
+	if result := m.Ladder(u, order); result.Sign() != 0 {
+		t.Errorf("Twist order does not annihilate %v", u)
+	}
+}
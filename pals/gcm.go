@@ -0,0 +1,230 @@
+package pals
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	gcmBlockSize         = 16
+	gcmStandardNonceSize = 12
+	gcmTagSize           = 16
+)
+
+// ErrGCMAuthentication is returned by Open when the tag doesn't match
+var ErrGCMAuthentication = errors.New("message authentication failed")
+
+type gcm struct {
+	b         cipher.Block
+	h         *ghashTable
+	nonceSize int
+	tagSize   int
+}
+
+// NewGCM returns the given 128 bit block cipher in Galois/Counter Mode,
+// with the standard 12 byte nonce and 16 byte tag
+func NewGCM(block cipher.Block) (cipher.AEAD, error) {
+	return newGCM(block, gcmStandardNonceSize, gcmTagSize)
+}
+
+// NewGCMWithNonceSize is like NewGCM, but accepts nonces of the given length
+func NewGCMWithNonceSize(block cipher.Block, size int) (cipher.AEAD, error) {
+	return newGCM(block, size, gcmTagSize)
+}
+
+// NewGCMWithTagSize is like NewGCM, but produces tags of the given length.
+// Unlike crypto/cipher, any length from 1 to 16 bytes is accepted, however
+// insecure.
+func NewGCMWithTagSize(block cipher.Block, size int) (cipher.AEAD, error) {
+	return newGCM(block, gcmStandardNonceSize, size)
+}
+
+func newGCM(block cipher.Block, nonceSize, tagSize int) (cipher.AEAD, error) {
+	if block.BlockSize() != gcmBlockSize {
+		return nil, fmt.Errorf("GCM requires a 128 bit block cipher")
+	}
+	if nonceSize < 1 {
+		return nil, fmt.Errorf("invalid GCM nonce size %v", nonceSize)
+	}
+	if tagSize < 1 || tagSize > gcmTagSize {
+		return nil, fmt.Errorf("invalid GCM tag size %v", tagSize)
+	}
+	// The authentication key is the encryption of the zero block
+	h := make([]byte, gcmBlockSize)
+	block.Encrypt(h, h)
+	return &gcm{b: block, h: newGHASHTable(GF128FromBytes(h)), nonceSize: nonceSize, tagSize: tagSize}, nil
+}
+
+// NonceSize returns the size of the nonce Seal and Open expect
+func (g *gcm) NonceSize() int {
+	return g.nonceSize
+}
+
+// Overhead returns the length of the tag appended to the ciphertext
+func (g *gcm) Overhead() int {
+	return g.tagSize
+}
+
+// Seal encrypts and authenticates plaintext, authenticates the additional
+// data and appends ciphertext || tag to dst
+func (g *gcm) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != g.nonceSize {
+		panic("gcm: incorrect nonce length given to GCM")
+	}
+	j0 := g.counter0(nonce)
+	out := make([]byte, len(plaintext), len(plaintext)+g.tagSize)
+	g.ctr(out, plaintext, j0)
+	out = append(out, g.tag(j0, additionalData, out)...)
+	return append(dst, out...)
+}
+
+// Open authenticates the ciphertext and additional data, and if they are
+// genuine decrypts the ciphertext and appends it to dst
+func (g *gcm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != g.nonceSize {
+		panic("gcm: incorrect nonce length given to GCM")
+	}
+	if len(ciphertext) < g.tagSize {
+		return nil, ErrGCMAuthentication
+	}
+	tag := ciphertext[len(ciphertext)-g.tagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-g.tagSize]
+
+	j0 := g.counter0(nonce)
+	if subtle.ConstantTimeCompare(g.tag(j0, additionalData, ciphertext), tag) != 1 {
+		return nil, ErrGCMAuthentication
+	}
+	out := make([]byte, len(ciphertext))
+	g.ctr(out, ciphertext, j0)
+	return append(dst, out...), nil
+}
+
+// counter0 derives the pre-counter block J0 from the nonce. For the
+// standard nonce size, it's nonce || 0^31 || 1, otherwise GHASH(nonce).
+func (g *gcm) counter0(nonce []byte) []byte {
+	if len(nonce) == gcmStandardNonceSize {
+		j0 := make([]byte, gcmBlockSize)
+		copy(j0, nonce)
+		j0[gcmBlockSize-1] = 1
+		return j0
+	}
+	return g.h.ghash(nil, nonce).Bytes()
+}
+
+// ctr encrypts src into dst in counter mode, starting from inc32(j0)
+func (g *gcm) ctr(dst, src, j0 []byte) {
+	counter := make([]byte, gcmBlockSize)
+	copy(counter, j0)
+	keystream := make([]byte, gcmBlockSize)
+	for i := 0; i < len(src); i += gcmBlockSize {
+		inc32(counter)
+		g.b.Encrypt(keystream, counter)
+		end := i + gcmBlockSize
+		if end > len(src) {
+			end = len(src)
+		}
+		for j := i; j < end; j++ {
+			dst[j] = src[j] ^ keystream[j-i]
+		}
+	}
+}
+
+// tag computes the possibly truncated E(K, J0) + GHASH(H, A, C)
+func (g *gcm) tag(j0, additionalData, ciphertext []byte) []byte {
+	mask := make([]byte, gcmBlockSize)
+	g.b.Encrypt(mask, j0)
+	s := g.h.ghash(additionalData, ciphertext).Add(GF128FromBytes(mask))
+	return s.Bytes()[:g.tagSize]
+}
+
+// inc32 increments the last 32 bits of the counter block, modulo 2^32
+func inc32(counter []byte) {
+	c := binary.BigEndian.Uint32(counter[gcmBlockSize-4:])
+	binary.BigEndian.PutUint32(counter[gcmBlockSize-4:], c+1)
+}
+
+// GHASH computes the GCM hash of the additional data and ciphertext under
+// the authentication key h. Both are zero padded to a multiple of the block
+// size, followed by a block holding their lengths in bits, and the blocks
+// b_1 .. b_n are evaluated as the polynomial b_1*h^n + ... + b_n*h.
+func GHASH(h GF128, additionalData, ciphertext []byte) GF128 {
+	return newGHASHTable(h).ghash(additionalData, ciphertext)
+}
+
+// ghashTable holds the products of h with all polynomials of degree below
+// 8, so that multiplying by h takes 16 table lookups rather than 128
+// conditional additions (Shoup's table method).
+type ghashTable [256]GF128
+
+// ghashReduction[b] is the reduction of the coefficients of x^128 .. x^135
+// that shifting by x^8 pushes out of the low byte b, which only touches the
+// top bits of hi
+var ghashReduction = func() (r [256]uint64) {
+	x8 := GF128{hi: 1 << 55}
+	for b := range r {
+		r[b] = GF128{lo: uint64(b)}.Mul(x8).hi
+	}
+	return r
+}()
+
+func newGHASHTable(h GF128) *ghashTable {
+	// Bit k of an index is the coefficient of x^(7-k), so the top bit is
+	// the constant term
+	var t ghashTable
+	t[0x80] = h
+	for bit := 0x40; bit > 0; bit >>= 1 {
+		t[bit] = t[bit<<1].Mul(gf128X)
+	}
+	for i := 1; i < len(t); i++ {
+		if low := i & -i; low != i {
+			t[i] = t[i^low].Add(t[low])
+		}
+	}
+	return &t
+}
+
+// gf128X is the element x
+var gf128X = GF128{hi: 1 << 62}
+
+// mul returns y * h, processing y eight coefficients at a time from the
+// highest degree down, Horner style
+func (t *ghashTable) mul(y GF128) GF128 {
+	var z GF128
+	for _, word := range [2]uint64{y.lo, y.hi} {
+		for j := 0; j < 64; j += 8 {
+			// z = z * x^8
+			out := z.lo & 0xff
+			z.lo = z.lo>>8 | z.hi<<56
+			z.hi = z.hi>>8 ^ ghashReduction[out]
+			e := &t[word&0xff]
+			z.hi ^= e.hi
+			z.lo ^= e.lo
+			word >>= 8
+		}
+	}
+	return z
+}
+
+func (t *ghashTable) ghash(additionalData, ciphertext []byte) GF128 {
+	var y GF128
+	block := make([]byte, gcmBlockSize)
+	for _, data := range [][]byte{additionalData, ciphertext} {
+		for i := 0; i < len(data); i += gcmBlockSize {
+			b := data[i:]
+			if len(b) < gcmBlockSize {
+				copy(block, b)
+				for j := len(b); j < gcmBlockSize; j++ {
+					block[j] = 0
+				}
+				b = block
+			}
+			y = t.mul(y.Add(GF128FromBytes(b)))
+		}
+	}
+	binary.BigEndian.PutUint64(block[:8], uint64(len(additionalData))*8)
+	binary.BigEndian.PutUint64(block[8:], uint64(len(ciphertext))*8)
+	return t.mul(y.Add(GF128FromBytes(block)))
+}
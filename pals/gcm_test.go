@@ -0,0 +1,136 @@
+package pals
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+)
+
+func TestGCMVectors(t *testing.T) {
+	// Test cases 1-6 from the GCM specification
+	const (
+		k   = "feffe9928665731c6d6a8f9467308308"
+		p   = "d9313225f88406e5a55909c5aff5269a86a7a9531534f7da2e4c303d8a318a721c3c0c95956809532fcf0e2449a6b525b16aedf5aa0de657ba637b391aafd255"
+		aad = "feedfacedeadbeeffeedfacedeadbeefabaddad2"
+	)
+	ex := []struct {
+		key, iv, plain, aad, crypt, tag string
+	}{
+		{"00000000000000000000000000000000", "000000000000000000000000", "", "", "", "58e2fccefa7e3061367f1d57a4e7455a"},
+		{"00000000000000000000000000000000", "000000000000000000000000", "00000000000000000000000000000000", "",
+			"0388dace60b6a392f328c2b971b2fe78", "ab6e47d42cec13bdf53a67b21257bddf"},
+		{k, "cafebabefacedbaddecaf888", p, "",
+			"42831ec2217774244b7221b784d0d49ce3aa212f2c02a4e035c17e2329aca12e21d514b25466931c7d8f6a5aac84aa051ba30b396a0aac973d58e091473f5985",
+			"4d5c2af327cd64a62cf35abd2ba6fab4"},
+		{k, "cafebabefacedbaddecaf888", p[:120], aad,
+			"42831ec2217774244b7221b784d0d49ce3aa212f2c02a4e035c17e2329aca12e21d514b25466931c7d8f6a5aac84aa051ba30b396a0aac973d58e091",
+			"5bc94fbc3221a5db94fae95ae7121a47"},
+		{k, "cafebabefacedbad", p[:120], aad,
+			"61353b4c2806934a777ff51fa22a4755699b2a714fcdc6f83766e5f97b6c742373806900e49f24b22b097544d4896b424989b5e1ebac0f07c23f4598",
+			"3612d2e79e3b0785561be14aaca2fccb"},
+		{k, "9313225df88406e555909c5aff5269aa6a7a9538534f7da1e4c303d2a318a728c3c0c95156809539fcf0e2429a6b525416aedbf5a0de6a57a637b39b", p[:120], aad,
+			"8ce24998625615b603a033aca13fb894be9112a5c3a211a8ba262a3cca7e2ca701e4a9a4fba43c90ccdcb281d48c7c6fd62875d2aca417034c34aee5",
+			"619cc5aefffe0bfa462af43c1699d050"},
+	}
+	for i, e := range ex {
+		key, _ := hex.DecodeString(e.key)
+		iv, _ := hex.DecodeString(e.iv)
+		plain, _ := hex.DecodeString(e.plain)
+		aad, _ := hex.DecodeString(e.aad)
+		expected, _ := hex.DecodeString(e.crypt + e.tag)
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatalf("failed to create cipher: %v", err)
+		}
+		aead, err := NewGCMWithNonceSize(block, len(iv))
+		if err != nil {
+			t.Fatalf("failed to create GCM: %v", err)
+		}
+		result := aead.Seal(nil, iv, plain, aad)
+		if !bytes.Equal(result, expected) {
+			t.Errorf("GCM test case %v failed: \nExp: %x \nGot: %x", i+1, expected, result)
+		}
+		decrypted, err := aead.Open(nil, iv, result, aad)
+		if err != nil || !bytes.Equal(decrypted, plain) {
+			t.Errorf("GCM test case %v failed to decrypt: %v", i+1, err)
+		}
+	}
+}
+
+func TestGCMAgainstStdlib(t *testing.T) {
+	rng := rand.New(rand.NewSource(47))
+	key := make([]byte, 16)
+	rng.Read(key)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+	for _, tagSize := range []int{12, 16} {
+		ours, err := NewGCMWithTagSize(block, tagSize)
+		if err != nil {
+			t.Fatalf("failed to create GCM: %v", err)
+		}
+		theirs, err := cipher.NewGCMWithTagSize(block, tagSize)
+		if err != nil {
+			t.Fatalf("failed to create stdlib GCM: %v", err)
+		}
+		for _, n := range []int{0, 1, 15, 16, 17, 100} {
+			nonce, plain, aad := make([]byte, 12), make([]byte, n), make([]byte, n/3)
+			rng.Read(nonce)
+			rng.Read(plain)
+			rng.Read(aad)
+
+			expected := theirs.Seal(nil, nonce, plain, aad)
+			if result := ours.Seal(nil, nonce, plain, aad); !bytes.Equal(result, expected) {
+				t.Errorf("Seal of %v bytes with %v byte tag differs from crypto/cipher: \nExp: %x \nGot: %x", n, tagSize, expected, result)
+			}
+		}
+	}
+}
+
+func TestGCMOpenTampered(t *testing.T) {
+	block, err := aes.NewCipher([]byte("YELLOW SUBMARINE"))
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+	aead, err := NewGCM(block)
+	if err != nil {
+		t.Fatalf("failed to create GCM: %v", err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	sealed := aead.Seal(nil, nonce, []byte("attack at dawn"), []byte("header"))
+
+	for i := range sealed {
+		tampered := append([]byte{}, sealed...)
+		tampered[i] ^= 1
+		if _, err := aead.Open(nil, nonce, tampered, []byte("header")); err != ErrGCMAuthentication {
+			t.Errorf("Open accepted ciphertext tampered at byte %v", i)
+		}
+	}
+	if _, err := aead.Open(nil, nonce, sealed, []byte("Header")); err != ErrGCMAuthentication {
+		t.Errorf("Open accepted tampered additional data")
+	}
+}
+
+func TestGHASHTable(t *testing.T) {
+	rng := rand.New(rand.NewSource(49))
+	for i := 0; i < 20; i++ {
+		h, y := randomGF128(rng), randomGF128(rng)
+		if result, expected := newGHASHTable(h).mul(y), y.Mul(h); result != expected {
+			t.Errorf("Table multiplication of %x by %x failed: \nExp: %x \nGot: %x", y.Bytes(), h.Bytes(), expected.Bytes(), result.Bytes())
+		}
+	}
+}
+
+func BenchmarkGHASH(b *testing.B) {
+	h := GF128FromBytes([]byte("YELLOW SUBMARINE"))
+	data := make([]byte, 1<<16)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		GHASH(h, nil, data)
+	}
+}
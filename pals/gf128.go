@@ -0,0 +1,129 @@
+package pals
+
+import (
+	"encoding/binary"
+	"math/big"
+)
+
+// GF128Modulus is the polynomial x^128 + x^7 + x^2 + x + 1 defining the
+// field GF(2^128) used by GCM
+var GF128Modulus = NewGF2Poly(128, 7, 2, 1, 0)
+
+// GF128 is an element of GF(2^128) in GCM's bit order: the most
+// significant bit of the first byte is the coefficient of x^0, and the
+// least significant bit of the last byte that of x^127.
+type GF128 struct {
+	hi, lo uint64
+}
+
+// GF128One is the multiplicative identity
+var GF128One = GF128{hi: 1 << 63}
+
+// GF128FromBytes converts a 16 byte block into a field element
+func GF128FromBytes(b []byte) GF128 {
+	return GF128{hi: binary.BigEndian.Uint64(b[:8]), lo: binary.BigEndian.Uint64(b[8:16])}
+}
+
+// Bytes converts a field element into a 16 byte block
+func (a GF128) Bytes() []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], a.hi)
+	binary.BigEndian.PutUint64(b[8:], a.lo)
+	return b
+}
+
+// GF128FromPoly converts a polynomial into a field element, reducing it
+// modulo GF128Modulus
+func GF128FromPoly(p *GF2Poly) GF128 {
+	r := new(GF2Poly).Mod(p, GF128Modulus)
+	var a GF128
+	for i := 0; i < 128; i++ {
+		a = a.SetCoefficient(i, r.Coefficient(i))
+	}
+	return a
+}
+
+// Poly converts a field element into a polynomial of degree below 128
+func (a GF128) Poly() *GF2Poly {
+	p := new(GF2Poly)
+	for i := 0; i < 128; i++ {
+		if a.Coefficient(i) == 1 {
+			p.bits.SetBit(&p.bits, i, 1)
+		}
+	}
+	return p
+}
+
+// Coefficient returns the coefficient of x^i in a
+func (a GF128) Coefficient(i int) uint {
+	if i < 64 {
+		return uint(a.hi>>(63-uint(i))) & 1
+	}
+	return uint(a.lo>>(127-uint(i))) & 1
+}
+
+// SetCoefficient returns a with the coefficient of x^i set to c
+func (a GF128) SetCoefficient(i int, c uint) GF128 {
+	if i < 64 {
+		a.hi = a.hi&^(1<<(63-uint(i))) | uint64(c)<<(63-uint(i))
+	} else {
+		a.lo = a.lo&^(1<<(127-uint(i))) | uint64(c)<<(127-uint(i))
+	}
+	return a
+}
+
+// IsZero reports whether a is zero
+func (a GF128) IsZero() bool {
+	return a.hi == 0 && a.lo == 0
+}
+
+// Add returns a + b, which is also a - b
+func (a GF128) Add(b GF128) GF128 {
+	return GF128{hi: a.hi ^ b.hi, lo: a.lo ^ b.lo}
+}
+
+// Mul returns a * b, using the shift and add algorithm from the GCM
+// specification
+func (a GF128) Mul(b GF128) GF128 {
+	var z GF128
+	v := b
+	for i := 0; i < 128; i++ {
+		if a.Coefficient(i) == 1 {
+			z.hi ^= v.hi
+			z.lo ^= v.lo
+		}
+		// v = v * x, reducing x^128 to x^7 + x^2 + x + 1
+		carry := v.lo & 1
+		v.lo = v.lo>>1 | v.hi<<63
+		v.hi >>= 1
+		if carry == 1 {
+			v.hi ^= 0xe1 << 56
+		}
+	}
+	return z
+}
+
+// Exp returns a^e
+func (a GF128) Exp(e *big.Int) GF128 {
+	result := GF128One
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		result = result.Mul(result)
+		if e.Bit(i) == 1 {
+			result = result.Mul(a)
+		}
+	}
+	return result
+}
+
+// gf128InverseExp is 2^128 - 2, since a^(2^128 - 1) = 1 for non-zero a
+var gf128InverseExp = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(2))
+
+// Inverse returns a^-1. The inverse of zero is zero.
+func (a GF128) Inverse() GF128 {
+	return a.Exp(gf128InverseExp)
+}
+
+// Div returns a / b
+func (a GF128) Div(b GF128) GF128 {
+	return a.Mul(b.Inverse())
+}
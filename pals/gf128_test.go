@@ -0,0 +1,43 @@
+package pals
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func randomGF128(rng *rand.Rand) GF128 {
+	return GF128{hi: rng.Uint64(), lo: rng.Uint64()}
+}
+
+func TestGF128Mul(t *testing.T) {
+	rng := rand.New(rand.NewSource(47))
+	for i := 0; i < 20; i++ {
+		a, b := randomGF128(rng), randomGF128(rng)
+		expected := GF128FromPoly(new(GF2Poly).Mul(a.Poly(), b.Poly()))
+		if result := a.Mul(b); result != expected {
+			t.Errorf("Mul(%x, %x) failed: \nExp: %x \nGot: %x", a.Bytes(), b.Bytes(), expected.Bytes(), result.Bytes())
+		}
+		if GF128FromPoly(a.Poly()) != a {
+			t.Errorf("Poly round trip of %x failed", a.Bytes())
+		}
+	}
+}
+
+func TestGF128Inverse(t *testing.T) {
+	rng := rand.New(rand.NewSource(47))
+	for i := 0; i < 5; i++ {
+		a := randomGF128(rng)
+		if result := a.Mul(a.Inverse()); result != GF128One {
+			t.Errorf("Inverse of %x failed: a * a^-1 = %x", a.Bytes(), result.Bytes())
+		}
+		b := randomGF128(rng)
+		if result := a.Mul(b).Div(b); result != a {
+			t.Errorf("Div failed: \nExp: %x \nGot: %x", a.Bytes(), result.Bytes())
+		}
+	}
+	x := GF128FromPoly(NewGF2Poly(1))
+	if result := x.Exp(big.NewInt(128)); result != GF128FromPoly(NewGF2Poly(7, 2, 1, 0)) {
+		t.Errorf("x^128 failed: got %v", result.Poly())
+	}
+}
@@ -0,0 +1,345 @@
+package pals
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// GF128Poly is a polynomial over GF(2^128), with p[i] holding the
+// coefficient of x^i. Results are always normalized so that the leading
+// coefficient is non-zero; the zero polynomial is empty.
+type GF128Poly []GF128
+
+// NewGF128Poly returns the polynomial with the given coefficients, lowest
+// degree first
+func NewGF128Poly(coeffs ...GF128) GF128Poly {
+	return GF128Poly(append([]GF128{}, coeffs...)).normalize()
+}
+
+func (p GF128Poly) normalize() GF128Poly {
+	for len(p) > 0 && p[len(p)-1].IsZero() {
+		p = p[:len(p)-1]
+	}
+	return p
+}
+
+// Degree returns the degree of p, or -1 for the zero polynomial
+func (p GF128Poly) Degree() int {
+	return len(p.normalize()) - 1
+}
+
+// Equal reports whether p and q are the same polynomial
+func (p GF128Poly) Equal(q GF128Poly) bool {
+	p, q = p.normalize(), q.normalize()
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Eval evaluates p at x using Horner's method
+func (p GF128Poly) Eval(x GF128) GF128 {
+	var y GF128
+	for i := len(p) - 1; i >= 0; i-- {
+		y = y.Mul(x).Add(p[i])
+	}
+	return y
+}
+
+// Add returns p + q, which is also p - q
+func (p GF128Poly) Add(q GF128Poly) GF128Poly {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	sum := append(GF128Poly{}, p...)
+	for i := range q {
+		sum[i] = sum[i].Add(q[i])
+	}
+	return sum.normalize()
+}
+
+// Mul returns p * q
+func (p GF128Poly) Mul(q GF128Poly) GF128Poly {
+	p, q = p.normalize(), q.normalize()
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	prod := make(GF128Poly, len(p)+len(q)-1)
+	for i := range p {
+		for j := range q {
+			prod[i+j] = prod[i+j].Add(p[i].Mul(q[j]))
+		}
+	}
+	return prod.normalize()
+}
+
+// DivMod returns the quotient and remainder of p / q. It panics if q is zero.
+func (p GF128Poly) DivMod(q GF128Poly) (quo, rem GF128Poly) {
+	q = q.normalize()
+	if len(q) == 0 {
+		panic("GF128Poly: division by zero")
+	}
+	rem = append(GF128Poly{}, p.normalize()...)
+	if len(rem) < len(q) {
+		return nil, rem
+	}
+	quo = make(GF128Poly, len(rem)-len(q)+1)
+	lead := q[len(q)-1].Inverse()
+	for len(rem) >= len(q) {
+		shift := len(rem) - len(q)
+		c := rem[len(rem)-1].Mul(lead)
+		quo[shift] = c
+		for i := range q {
+			rem[shift+i] = rem[shift+i].Add(c.Mul(q[i]))
+		}
+		rem = rem.normalize()
+	}
+	return quo.normalize(), rem
+}
+
+// Mod returns p mod q
+func (p GF128Poly) Mod(q GF128Poly) GF128Poly {
+	_, rem := p.DivMod(q)
+	return rem
+}
+
+// Monic returns p divided by its leading coefficient
+func (p GF128Poly) Monic() GF128Poly {
+	p = p.normalize()
+	if len(p) == 0 {
+		return nil
+	}
+	inv := p[len(p)-1].Inverse()
+	monic := make(GF128Poly, len(p))
+	for i := range p {
+		monic[i] = p[i].Mul(inv)
+	}
+	return monic
+}
+
+// GCD returns the monic greatest common divisor of p and q
+func (p GF128Poly) GCD(q GF128Poly) GF128Poly {
+	a, b := p.normalize(), q.normalize()
+	for len(b) > 0 {
+		a, b = b, a.Mod(b)
+	}
+	return a.Monic()
+}
+
+// ExpMod returns p^e mod m
+func (p GF128Poly) ExpMod(e *big.Int, m GF128Poly) GF128Poly {
+	result := GF128Poly{GF128One}.Mod(m)
+	base := p.Mod(m)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		result = result.Mul(result).Mod(m)
+		if e.Bit(i) == 1 {
+			result = result.Mul(base).Mod(m)
+		}
+	}
+	return result
+}
+
+// Derivative returns the formal derivative of p. In characteristic 2 the
+// even powers vanish.
+func (p GF128Poly) Derivative() GF128Poly {
+	if len(p) < 2 {
+		return nil
+	}
+	d := make(GF128Poly, len(p)-1)
+	for i := 1; i < len(p); i += 2 {
+		d[i-1] = p[i]
+	}
+	return d.normalize()
+}
+
+// sqrt returns q with q^2 = p, given that p only has even powers of x.
+// The square root of a field element a is a^(2^127).
+func (p GF128Poly) sqrt() GF128Poly {
+	e := new(big.Int).Lsh(big.NewInt(1), 127)
+	q := make(GF128Poly, (len(p)+1)/2)
+	for i := range q {
+		q[i] = p[2*i].Exp(e)
+	}
+	return q.normalize()
+}
+
+// isOne reports whether p is the constant 1
+func (p GF128Poly) isOne() bool {
+	p = p.normalize()
+	return len(p) == 1 && p[0] == GF128One
+}
+
+// Factor is a factor of a polynomial, along with its multiplicity or degree
+type Factor struct {
+	Poly GF128Poly
+	N    int
+}
+
+// SquareFreeFactors splits the monic polynomial p into square-free
+// factors. N is each factor's multiplicity in p.
+func (p GF128Poly) SquareFreeFactors() []Factor {
+	p = p.Monic()
+	if p.Degree() < 1 {
+		return nil
+	}
+	var out []Factor
+	d := p.Derivative()
+	if len(d) == 0 {
+		// p(x) = q(x^2) = q'(x)^2
+		for _, f := range p.sqrt().SquareFreeFactors() {
+			out = append(out, Factor{f.Poly, 2 * f.N})
+		}
+		return out
+	}
+
+	c := p.GCD(d)
+	w, _ := p.DivMod(c)
+	for i := 1; !w.isOne(); i++ {
+		y := w.GCD(c)
+		fac, _ := w.DivMod(y)
+		if !fac.isOne() {
+			out = append(out, Factor{fac.Monic(), i})
+		}
+		w = y
+		c, _ = c.DivMod(y)
+	}
+	if !c.isOne() {
+		// What's left is a perfect square
+		for _, f := range c.sqrt().SquareFreeFactors() {
+			out = append(out, Factor{f.Poly, 2 * f.N})
+		}
+	}
+	return out
+}
+
+// frobenius returns x^(2^(128*d)) mod m, starting from h = x^(2^(128*(d-1)))
+func frobenius(h, m GF128Poly) GF128Poly {
+	for i := 0; i < 128; i++ {
+		h = h.Mul(h).Mod(m)
+	}
+	return h
+}
+
+// DistinctDegreeFactors splits the monic square-free polynomial p into
+// factors that are each the product of all its irreducible factors of
+// degree N
+func (p GF128Poly) DistinctDegreeFactors() []Factor {
+	var out []Factor
+	x := GF128Poly{GF128{}, GF128One}
+	rest := p.Monic()
+	h := x.Mod(rest)
+	for d := 1; rest.Degree() >= 2*d; d++ {
+		// The irreducible polynomials of degree dividing d are exactly the
+		// factors of x^(q^d) - x, where q = 2^128
+		h = frobenius(h, rest)
+		g := rest.GCD(h.Add(x))
+		if !g.isOne() {
+			out = append(out, Factor{g, d})
+			rest, _ = rest.DivMod(g)
+			h = h.Mod(rest)
+		}
+	}
+	if rest.Degree() > 0 {
+		out = append(out, Factor{rest, rest.Degree()})
+	}
+	return out
+}
+
+// EqualDegreeFactors splits the monic square-free polynomial p, all of
+// whose irreducible factors have degree d, into those factors using the
+// Cantor-Zassenhaus algorithm.
+//
+// In characteristic 2, the trace map T(h) = h + h^2 + ... + h^(2^(128d-1))
+// sends every element of GF(2^(128d)) to 0 or 1, so for random h, gcd(p,
+// T(h)) contains about half of the factors.
+func (p GF128Poly) EqualDegreeFactors(d int, rand io.Reader) ([]GF128Poly, error) {
+	p = p.Monic()
+	n := p.Degree()
+	if d < 1 || n%d != 0 {
+		return nil, fmt.Errorf("degree %v is not a multiple of %v", n, d)
+	}
+	factors := []GF128Poly{p}
+	for len(factors) < n/d {
+		h, err := randomGF128Poly(n, rand)
+		if err != nil {
+			return nil, err
+		}
+		t := h
+		for i := 1; i < 128*d; i++ {
+			h = h.Mul(h).Mod(p)
+			t = t.Add(h)
+		}
+
+		var next []GF128Poly
+		for _, u := range factors {
+			if u.Degree() == d {
+				next = append(next, u)
+				continue
+			}
+			g := u.GCD(t)
+			if g.isOne() || g.Degree() == u.Degree() {
+				next = append(next, u)
+				continue
+			}
+			v, _ := u.DivMod(g)
+			next = append(next, g, v.Monic())
+		}
+		factors = next
+	}
+	return factors, nil
+}
+
+// Roots returns the distinct roots of p in GF(2^128)
+func (p GF128Poly) Roots(rand io.Reader) ([]GF128, error) {
+	var roots []GF128
+	for _, sf := range p.SquareFreeFactors() {
+		for _, df := range sf.Poly.DistinctDegreeFactors() {
+			if df.N != 1 {
+				continue
+			}
+			linear, err := df.Poly.EqualDegreeFactors(1, rand)
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range linear {
+				// l = x + r
+				roots = append(roots, l[0])
+			}
+		}
+	}
+	return roots, nil
+}
+
+// randomGF128Poly returns a random polynomial of degree below n
+func randomGF128Poly(n int, rand io.Reader) (GF128Poly, error) {
+	p := make(GF128Poly, n)
+	b := make([]byte, 16)
+	for i := range p {
+		if _, err := io.ReadFull(rand, b); err != nil {
+			return nil, fmt.Errorf("failed to generate coefficient: %v", err)
+		}
+		p[i] = GF128FromBytes(b)
+	}
+	return p.normalize(), nil
+}
+
+func (p GF128Poly) String() string {
+	var terms []string
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].IsZero() {
+			continue
+		}
+		terms = append(terms, fmt.Sprintf("%x*x^%v", p[i].Bytes(), i))
+	}
+	if len(terms) == 0 {
+		return "0"
+	}
+	return strings.Join(terms, " + ")
+}
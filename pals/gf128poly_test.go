@@ -0,0 +1,100 @@
+package pals
+
+import (
+	"crypto/rand"
+	mrand "math/rand"
+	"testing"
+)
+
+// linear returns x + r
+func linear(r GF128) GF128Poly {
+	return NewGF128Poly(r, GF128One)
+}
+
+func TestGF128PolyDivMod(t *testing.T) {
+	rng := mrand.New(mrand.NewSource(48))
+	for i := 0; i < 10; i++ {
+		a := NewGF128Poly(randomGF128(rng), randomGF128(rng), randomGF128(rng), randomGF128(rng), randomGF128(rng))
+		b := NewGF128Poly(randomGF128(rng), randomGF128(rng), randomGF128(rng))
+		q, r := a.DivMod(b)
+		if r.Degree() >= b.Degree() || !q.Mul(b).Add(r).Equal(a) {
+			t.Errorf("DivMod failed: \n%v = (%v)(%v) + %v", a, q, b, r)
+		}
+		x := randomGF128(rng)
+		if a.Mul(b).Eval(x) != a.Eval(x).Mul(b.Eval(x)) {
+			t.Errorf("Mul does not agree with Eval")
+		}
+	}
+}
+
+func TestGF128PolyGCD(t *testing.T) {
+	rng := mrand.New(mrand.NewSource(48))
+	r1, r2, r3 := randomGF128(rng), randomGF128(rng), randomGF128(rng)
+	a := linear(r1).Mul(linear(r2))
+	b := linear(r2).Mul(linear(r3))
+	if g := a.GCD(b); !g.Equal(linear(r2)) {
+		t.Errorf("GCD failed: \nExp: %v \nGot: %v", linear(r2), g)
+	}
+}
+
+func TestGF128PolyFactors(t *testing.T) {
+	rng := mrand.New(mrand.NewSource(48))
+	r1, r2, r3 := randomGF128(rng), randomGF128(rng), randomGF128(rng)
+	cubic := NewGF128Poly(randomGF128(rng), randomGF128(rng), randomGF128(rng), GF128One)
+
+	// (x + r1) (x + r2)^2 (x + r3)^3 cubic
+	p := linear(r1).Mul(linear(r2)).Mul(linear(r2)).Mul(linear(r3)).Mul(linear(r3)).Mul(linear(r3)).Mul(cubic)
+
+	sff := p.SquareFreeFactors()
+	product := NewGF128Poly(GF128One)
+	for _, f := range sff {
+		for i := 0; i < f.N; i++ {
+			product = product.Mul(f.Poly)
+		}
+		if !f.Poly.GCD(f.Poly.Derivative()).isOne() {
+			t.Errorf("Square-free factor %v is not square-free", f.Poly)
+		}
+	}
+	if !product.Equal(p) {
+		t.Errorf("Square-free factors do not multiply to p")
+	}
+
+	for _, f := range sff {
+		product := NewGF128Poly(GF128One)
+		for _, df := range f.Poly.DistinctDegreeFactors() {
+			if df.Poly.Degree()%df.N != 0 {
+				t.Errorf("Distinct degree factor of degree %v for degree %v", df.Poly.Degree(), df.N)
+			}
+			product = product.Mul(df.Poly)
+			factors, err := df.Poly.EqualDegreeFactors(df.N, rand.Reader)
+			if err != nil {
+				t.Fatalf("EqualDegreeFactors failed: %v", err)
+			}
+			for _, ef := range factors {
+				if ef.Degree() != df.N {
+					t.Errorf("Equal degree factor %v does not have degree %v", ef, df.N)
+				}
+			}
+		}
+		if !product.Equal(f.Poly) {
+			t.Errorf("Distinct degree factors do not multiply to %v", f.Poly)
+		}
+	}
+
+	roots, err := p.Roots(rand.Reader)
+	if err != nil {
+		t.Fatalf("Roots failed: %v", err)
+	}
+	found := make(map[GF128]bool)
+	for _, r := range roots {
+		if !p.Eval(r).IsZero() {
+			t.Errorf("Root %x is not a root", r.Bytes())
+		}
+		found[r] = true
+	}
+	for _, r := range []GF128{r1, r2, r3} {
+		if !found[r] {
+			t.Errorf("Roots did not find %x", r.Bytes())
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package pals
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// GF2Poly is a polynomial over GF(2), with bit i holding the coefficient
+// of x^i. Like big.Int, operations set the receiver and return it.
+// The zero value is the zero polynomial.
+type GF2Poly struct {
+	bits big.Int
+}
+
+// NewGF2Poly returns the sum of x^e for the given exponents
+func NewGF2Poly(exps ...int) *GF2Poly {
+	z := new(GF2Poly)
+	for _, e := range exps {
+		z.bits.SetBit(&z.bits, e, z.bits.Bit(e)^1)
+	}
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *GF2Poly) Set(x *GF2Poly) *GF2Poly {
+	z.bits.Set(&x.bits)
+	return z
+}
+
+// Degree returns the degree of z, or -1 for the zero polynomial
+func (z *GF2Poly) Degree() int {
+	return z.bits.BitLen() - 1
+}
+
+// Coefficient returns the coefficient of x^i
+func (z *GF2Poly) Coefficient(i int) uint {
+	return z.bits.Bit(i)
+}
+
+// Equal reports whether z and x are the same polynomial
+func (z *GF2Poly) Equal(x *GF2Poly) bool {
+	return z.bits.Cmp(&x.bits) == 0
+}
+
+// Add sets z to x + y, which is also x - y
+func (z *GF2Poly) Add(x, y *GF2Poly) *GF2Poly {
+	z.bits.Xor(&x.bits, &y.bits)
+	return z
+}
+
+// Mul sets z to x * y
+func (z *GF2Poly) Mul(x, y *GF2Poly) *GF2Poly {
+	var prod, shifted big.Int
+	for i := 0; i < y.bits.BitLen(); i++ {
+		if y.bits.Bit(i) == 1 {
+			prod.Xor(&prod, shifted.Lsh(&x.bits, uint(i)))
+		}
+	}
+	z.bits.Set(&prod)
+	return z
+}
+
+// DivMod sets z to the quotient x / y and m to the remainder x mod y,
+// and returns (z, m). It panics if y is zero.
+func (z *GF2Poly) DivMod(x, y, m *GF2Poly) (*GF2Poly, *GF2Poly) {
+	dy := y.Degree()
+	if dy < 0 {
+		panic("GF2Poly: division by zero")
+	}
+	var q, r, shifted big.Int
+	r.Set(&x.bits)
+	for d := r.BitLen() - 1; d >= dy; d = r.BitLen() - 1 {
+		q.SetBit(&q, d-dy, 1)
+		r.Xor(&r, shifted.Lsh(&y.bits, uint(d-dy)))
+	}
+	z.bits.Set(&q)
+	m.bits.Set(&r)
+	return z, m
+}
+
+// Mod sets z to x mod y
+func (z *GF2Poly) Mod(x, y *GF2Poly) *GF2Poly {
+	new(GF2Poly).DivMod(x, y, z)
+	return z
+}
+
+// GCD sets z to the greatest common divisor of x and y
+func (z *GF2Poly) GCD(x, y *GF2Poly) *GF2Poly {
+	a, b := new(GF2Poly).Set(x), new(GF2Poly).Set(y)
+	for b.Degree() >= 0 {
+		a, b = b, a.Mod(a, b)
+	}
+	return z.Set(a)
+}
+
+// ExpMod sets z to x^e mod m
+func (z *GF2Poly) ExpMod(x *GF2Poly, e *big.Int, m *GF2Poly) *GF2Poly {
+	result := NewGF2Poly(0)
+	base := new(GF2Poly).Mod(x, m)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		result.Mul(result, result).Mod(result, m)
+		if e.Bit(i) == 1 {
+			result.Mul(result, base).Mod(result, m)
+		}
+	}
+	return z.Set(result.Mod(result, m))
+}
+
+func (z *GF2Poly) String() string {
+	var terms []string
+	for i := z.Degree(); i >= 0; i-- {
+		if z.bits.Bit(i) == 0 {
+			continue
+		}
+		switch i {
+		case 0:
+			terms = append(terms, "1")
+		case 1:
+			terms = append(terms, "x")
+		default:
+			terms = append(terms, "x^"+strconv.Itoa(i))
+		}
+	}
+	if len(terms) == 0 {
+		return "0"
+	}
+	return strings.Join(terms, " + ")
+}
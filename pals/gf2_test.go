@@ -0,0 +1,45 @@
+package pals
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGF2PolyArithmetic(t *testing.T) {
+	ex := []struct {
+		x, y        *GF2Poly
+		prod, q, r  *GF2Poly
+		gcd         *GF2Poly
+		description string
+	}{
+		{NewGF2Poly(1, 0), NewGF2Poly(1, 0), NewGF2Poly(2, 0), NewGF2Poly(0), NewGF2Poly(), NewGF2Poly(1, 0), "(x+1)(x+1)"},
+		{NewGF2Poly(3, 0), NewGF2Poly(1, 0), NewGF2Poly(4, 3, 1, 0), NewGF2Poly(2, 1, 0), NewGF2Poly(), NewGF2Poly(1, 0), "(x^3+1)(x+1)"},
+		{NewGF2Poly(3, 1), NewGF2Poly(2, 1, 0), NewGF2Poly(5, 4, 2, 1), NewGF2Poly(1, 0), NewGF2Poly(1, 0), NewGF2Poly(0), "(x^3+x)(x^2+x+1)"},
+		{NewGF2Poly(4, 2), NewGF2Poly(3, 1), NewGF2Poly(7, 3), NewGF2Poly(1), NewGF2Poly(), NewGF2Poly(3, 1), "(x^4+x^2)(x^3+x)"},
+	}
+	for _, e := range ex {
+		if result := new(GF2Poly).Mul(e.x, e.y); !result.Equal(e.prod) {
+			t.Errorf("Mul %v failed: \nExp: %v \nGot: %v", e.description, e.prod, result)
+		}
+		q, r := new(GF2Poly).DivMod(e.x, e.y, new(GF2Poly))
+		if !q.Equal(e.q) || !r.Equal(e.r) {
+			t.Errorf("DivMod %v failed: \nExp: %v, %v \nGot: %v, %v", e.description, e.q, e.r, q, r)
+		}
+		if result := new(GF2Poly).GCD(e.x, e.y); !result.Equal(e.gcd) {
+			t.Errorf("GCD %v failed: \nExp: %v \nGot: %v", e.description, e.gcd, result)
+		}
+	}
+}
+
+func TestGF2PolyExpMod(t *testing.T) {
+	// x^(2^128) = x in GF(2^128), since the modulus is irreducible
+	e := new(big.Int).Lsh(big.NewInt(1), 128)
+	x := NewGF2Poly(1)
+	if result := new(GF2Poly).ExpMod(x, e, GF128Modulus); !result.Equal(x) {
+		t.Errorf("ExpMod failed: \nExp: %v \nGot: %v", x, result)
+	}
+	// (x+1)^3 = x^3 + x^2 + x + 1
+	if result := new(GF2Poly).ExpMod(NewGF2Poly(1, 0), big.NewInt(3), GF128Modulus); !result.Equal(NewGF2Poly(3, 2, 1, 0)) {
+		t.Errorf("ExpMod failed: \nExp: %v \nGot: %v", NewGF2Poly(3, 2, 1, 0), result)
+	}
+}
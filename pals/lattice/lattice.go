@@ -0,0 +1,173 @@
+// Package lattice implements lattice basis reduction over the rationals
+package lattice
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Vector is a vector of rationals
+type Vector []*big.Rat
+
+// NewVector returns a vector with the given integer entries
+func NewVector(xs ...int64) Vector {
+	v := make(Vector, len(xs))
+	for i, x := range xs {
+		v[i] = big.NewRat(x, 1)
+	}
+	return v
+}
+
+// Clone returns a deep copy of v
+func (v Vector) Clone() Vector {
+	c := make(Vector, len(v))
+	for i, x := range v {
+		c[i] = new(big.Rat).Set(x)
+	}
+	return c
+}
+
+// Equal reports whether v and w have the same entries
+func (v Vector) Equal(w Vector) bool {
+	if len(v) != len(w) {
+		return false
+	}
+	for i := range v {
+		if v[i].Cmp(w[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Dot returns the dot product of v and w
+func Dot(v, w Vector) *big.Rat {
+	sum, t := new(big.Rat), new(big.Rat)
+	for i := range v {
+		sum.Add(sum, t.Mul(v[i], w[i]))
+	}
+	return sum
+}
+
+// subScaled sets v = v - c*w
+func (v Vector) subScaled(c *big.Rat, w Vector) {
+	t := new(big.Rat)
+	for i := range v {
+		v[i].Sub(v[i], t.Mul(c, w[i]))
+	}
+}
+
+func (v Vector) String() string {
+	entries := make([]string, len(v))
+	for i, x := range v {
+		entries[i] = x.RatString()
+	}
+	return "(" + strings.Join(entries, ", ") + ")"
+}
+
+// GramSchmidt returns the orthogonalization b* of the basis, where
+// b*_i = b_i - sum(mu_ij * b*_j) for j < i, and the coefficients mu_ij
+func GramSchmidt(basis []Vector) (ortho []Vector, mu [][]*big.Rat) {
+	ortho = make([]Vector, len(basis))
+	mu = make([][]*big.Rat, len(basis))
+	norms := make([]*big.Rat, len(basis))
+	for i, b := range basis {
+		ortho[i] = b.Clone()
+		mu[i] = make([]*big.Rat, i)
+		for j := 0; j < i; j++ {
+			mu[i][j] = new(big.Rat).Quo(Dot(b, ortho[j]), norms[j])
+			ortho[i].subScaled(mu[i][j], ortho[j])
+		}
+		norms[i] = Dot(ortho[i], ortho[i])
+		if norms[i].Sign() == 0 {
+			panic(fmt.Sprintf("lattice: basis vector %v is linearly dependent", i))
+		}
+	}
+	return ortho, mu
+}
+
+// LLL returns the Lenstra-Lenstra-Lovász reduction of a basis of linearly
+// independent vectors, with Lovász parameter delta in (1/4, 1). The
+// reduced basis consists of short, nearly orthogonal vectors spanning the
+// same lattice; the first is within a factor of 2^((n-1)/2) of the
+// shortest non-zero lattice vector for delta = 3/4.
+//
+// Rather than orthogonalizing again after every change to the basis, the
+// Gram-Schmidt coefficients mu and squared norms B of b* are updated in
+// place, following Cohen's "A Course in Computational Algebraic Number
+// Theory", algorithm 2.6.3.
+func LLL(basis []Vector, delta *big.Rat) []Vector {
+	b := make([]Vector, len(basis))
+	for i, v := range basis {
+		b[i] = v.Clone()
+	}
+	if len(b) < 2 {
+		return b
+	}
+	ortho, mu := GramSchmidt(b)
+	norms := make([]*big.Rat, len(b))
+	for i, o := range ortho {
+		norms[i] = Dot(o, o)
+	}
+
+	half := big.NewRat(1, 2)
+	t, abs := new(big.Rat), new(big.Rat)
+	for k := 1; k < len(b); {
+		// Size reduce b_k, so that |mu_kj| <= 1/2 for all j < k
+		for j := k - 1; j >= 0; j-- {
+			if abs.Abs(mu[k][j]).Cmp(half) <= 0 {
+				continue
+			}
+			q := new(big.Rat).SetInt(round(mu[k][j]))
+			b[k].subScaled(q, b[j])
+			for i := 0; i < j; i++ {
+				mu[k][i].Sub(mu[k][i], t.Mul(q, mu[j][i]))
+			}
+			mu[k][j].Sub(mu[k][j], q)
+		}
+
+		// Lovász condition: B_k >= (delta - mu_k,k-1^2) B_k-1
+		bound := new(big.Rat).Mul(mu[k][k-1], mu[k][k-1])
+		bound.Sub(delta, bound)
+		bound.Mul(bound, norms[k-1])
+		if norms[k].Cmp(bound) >= 0 {
+			k++
+			continue
+		}
+
+		// Swap b_k and b_k-1, and update mu and B to match
+		m := mu[k][k-1]
+		bNew := new(big.Rat).Mul(m, m)
+		bNew.Mul(bNew, norms[k-1])
+		bNew.Add(bNew, norms[k])
+		mu[k][k-1] = new(big.Rat).Mul(m, norms[k-1])
+		mu[k][k-1].Quo(mu[k][k-1], bNew)
+		norms[k] = new(big.Rat).Mul(norms[k-1], norms[k])
+		norms[k].Quo(norms[k], bNew)
+		norms[k-1] = bNew
+
+		b[k], b[k-1] = b[k-1], b[k]
+		for j := 0; j < k-1; j++ {
+			mu[k][j], mu[k-1][j] = mu[k-1][j], mu[k][j]
+		}
+		for i := k + 1; i < len(b); i++ {
+			old := mu[i][k]
+			mu[i][k] = new(big.Rat).Sub(mu[i][k-1], t.Mul(m, old))
+			mu[i][k-1] = new(big.Rat).Add(old, t.Mul(mu[k][k-1], mu[i][k]))
+		}
+		if k > 1 {
+			k--
+		}
+	}
+	return b
+}
+
+// round returns the integer closest to r, rounding halves up
+func round(r *big.Rat) *big.Int {
+	// floor((2a + b) / 2b) for r = a/b with b > 0
+	num := new(big.Int).Lsh(r.Num(), 1)
+	num.Add(num, r.Denom())
+	den := new(big.Int).Lsh(r.Denom(), 1)
+	return num.Div(num, den)
+}
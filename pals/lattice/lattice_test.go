@@ -0,0 +1,109 @@
+package lattice
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func rat(a, b int64) *big.Rat {
+	return big.NewRat(a, b)
+}
+
+// checkReduced checks that the basis is size reduced and satisfies the
+// Lovász condition
+func checkReduced(t *testing.T, b []Vector, delta *big.Rat) {
+	ortho, mu := GramSchmidt(b)
+	half := rat(1, 2)
+	for i := range b {
+		for j := 0; j < i; j++ {
+			if new(big.Rat).Abs(mu[i][j]).Cmp(half) > 0 {
+				t.Errorf("basis is not size reduced: mu[%v][%v] = %v", i, j, mu[i][j].RatString())
+			}
+		}
+		if i == 0 {
+			continue
+		}
+		bound := new(big.Rat).Mul(mu[i][i-1], mu[i][i-1])
+		bound.Sub(delta, bound)
+		bound.Mul(bound, Dot(ortho[i-1], ortho[i-1]))
+		if Dot(ortho[i], ortho[i]).Cmp(bound) < 0 {
+			t.Errorf("basis fails the Lovász condition at %v", i)
+		}
+	}
+}
+
+func TestLLL(t *testing.T) {
+	delta := rat(99, 100)
+	basis := []Vector{
+		{rat(-2, 1), rat(0, 1), rat(2, 1), rat(0, 1)},
+		{rat(1, 2), rat(-1, 1), rat(0, 1), rat(0, 1)},
+		{rat(-1, 1), rat(0, 1), rat(-2, 1), rat(1, 2)},
+		{rat(-1, 1), rat(1, 1), rat(1, 1), rat(2, 1)},
+	}
+	expected := []Vector{
+		{rat(1, 2), rat(-1, 1), rat(0, 1), rat(0, 1)},
+		{rat(-1, 1), rat(0, 1), rat(-2, 1), rat(1, 2)},
+		{rat(-1, 2), rat(0, 1), rat(1, 1), rat(2, 1)},
+		{rat(-3, 2), rat(-1, 1), rat(2, 1), rat(0, 1)},
+	}
+	result := LLL(basis, delta)
+	for i := range expected {
+		if !result[i].Equal(expected[i]) {
+			t.Errorf("LLL failed: \nExp: %v \nGot: %v", expected, result)
+			break
+		}
+	}
+	checkReduced(t, result, delta)
+	if !basis[0].Equal(Vector{rat(-2, 1), rat(0, 1), rat(2, 1), rat(0, 1)}) {
+		t.Errorf("LLL modified its input")
+	}
+}
+
+func TestLLLRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(50))
+	delta := rat(3, 4)
+	for n := 2; n <= 10; n++ {
+		basis := make([]Vector, n)
+		for i := range basis {
+			basis[i] = make(Vector, n)
+			for j := range basis[i] {
+				basis[i][j] = rat(rng.Int63n(2001)-1000, 1)
+			}
+		}
+		result := LLL(basis, delta)
+		checkReduced(t, result, delta)
+
+		// The volume of the lattice is unchanged
+		before, after := rat(1, 1), rat(1, 1)
+		bo, _ := GramSchmidt(basis)
+		ao, _ := GramSchmidt(result)
+		for i := range bo {
+			before.Mul(before, Dot(bo[i], bo[i]))
+			after.Mul(after, Dot(ao[i], ao[i]))
+		}
+		if before.Cmp(after) != 0 {
+			t.Errorf("LLL changed the lattice volume for n = %v", n)
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	ex := []struct {
+		r        *big.Rat
+		expected int64
+	}{
+		{rat(7, 2), 4},
+		{rat(-7, 2), -3},
+		{rat(5, 3), 2},
+		{rat(-5, 3), -2},
+		{rat(4, 3), 1},
+		{rat(-4, 1), -4},
+	}
+	for _, e := range ex {
+		result := round(e.r)
+		if result.Int64() != e.expected {
+			t.Errorf("round(%v) failed: \nExp: %v \nGot: %v", e.r.RatString(), e.expected, result)
+		}
+	}
+}
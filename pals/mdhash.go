@@ -0,0 +1,86 @@
+package pals
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MDBlockSize is the block size of MDHash in bytes
+const MDBlockSize = 16
+
+// MDHash is a deliberately weak Merkle-Damgård hash function. Its
+// compression function encrypts the message block under AES, keyed with the
+// (zero padded) chaining state, and truncates the result to the state size.
+// The state is only a few bytes, which makes generic attacks on iterated
+// hashes cheap enough to demonstrate.
+type MDHash struct {
+	// Size is the size of the state and digest in bytes
+	Size int
+	// IV is the initial state
+	IV []byte
+}
+
+// NewMDHash returns a weak MD hash with a state of the given number of bits,
+// which must be a multiple of 8 between 16 and 32. The IV is derived from
+// the size so that hashes of different sizes differ.
+func NewMDHash(bits int) (*MDHash, error) {
+	if bits < 16 || bits > 32 || bits%8 != 0 {
+		return nil, fmt.Errorf("state size must be 16, 24 or 32 bits, got %v", bits)
+	}
+	size := bits / 8
+	iv := make([]byte, size)
+	for i := range iv {
+		iv[i] = byte(0xa5 ^ (bits + i))
+	}
+	return &MDHash{Size: size, IV: iv}, nil
+}
+
+// Compress applies the compression function to a single block
+func (h *MDHash) Compress(state, block []byte) []byte {
+	if len(block) != MDBlockSize {
+		panic("MDHash: block must be MDBlockSize bytes")
+	}
+	key := make([]byte, keySize)
+	copy(key, state)
+	out, err := AesEncryptECB(block, key)
+	if err != nil {
+		panic(err)
+	}
+	return out[:h.Size]
+}
+
+// Iterate runs the compression function over msg, which must consist of
+// full blocks, starting from the given state. No padding is added.
+func (h *MDHash) Iterate(state, msg []byte) []byte {
+	if len(msg)%MDBlockSize != 0 {
+		panic("MDHash: message must consist of full blocks")
+	}
+	for _, b := range ChunkBytes(msg, MDBlockSize) {
+		state = h.Compress(state, b)
+	}
+	return state
+}
+
+// Pad applies Merkle-Damgård strengthening to msg: a 0x80 byte, zeroes,
+// and the message length in bits as a 64 bit big endian number, so that
+// the result consists of full blocks.
+func (h *MDHash) Pad(msg []byte) []byte {
+	return append(append([]byte{}, msg...), MDPadding(len(msg))...)
+}
+
+// MDPadding returns the padding appended to a message of length n
+func MDPadding(n int) []byte {
+	padLen := MDBlockSize - (n+9)%MDBlockSize
+	if padLen == MDBlockSize {
+		padLen = 0
+	}
+	pad := make([]byte, 1+padLen+8)
+	pad[0] = 0x80
+	binary.BigEndian.PutUint64(pad[1+padLen:], uint64(n)*8)
+	return pad
+}
+
+// Sum returns the digest of msg
+func (h *MDHash) Sum(msg []byte) []byte {
+	return h.Iterate(h.IV, h.Pad(msg))
+}
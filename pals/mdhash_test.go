@@ -0,0 +1,50 @@
+package pals
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMDPadding(t *testing.T) {
+	for n := 0; n < 3*MDBlockSize; n++ {
+		pad := MDPadding(n)
+		if (n+len(pad))%MDBlockSize != 0 {
+			t.Errorf("Padding for length %v doesn't fill blocks: got %v bytes", n, len(pad))
+		}
+		if pad[0] != 0x80 || len(pad) < 9 {
+			t.Errorf("Padding for length %v malformed: %v", n, pad)
+		}
+	}
+}
+
+func TestMDHash(t *testing.T) {
+	for _, bits := range []int{16, 24, 32} {
+		h, err := NewMDHash(bits)
+		if err != nil {
+			t.Fatalf("failed to create %v bit hash: %v", bits, err)
+		}
+		a := h.Sum([]byte("YELLOW SUBMARINE"))
+		b := h.Sum([]byte("YELLOW SUBMARINE"))
+		c := h.Sum([]byte("YELLOW SUBMARINF"))
+		if len(a) != bits/8 {
+			t.Errorf("Digest has wrong size: Expected: %v Got: %v", bits/8, len(a))
+		}
+		if !bytes.Equal(a, b) {
+			t.Errorf("Hash (bits: %v) not deterministic: %x != %x", bits, a, b)
+		}
+		if bytes.Equal(a, c) {
+			t.Errorf("Hash (bits: %v) ignores input: %x == %x", bits, a, c)
+		}
+		// Iterating over the padded message must match Sum
+		msg := []byte("We all live in a yellow submarine")
+		if !bytes.Equal(h.Iterate(h.IV, h.Pad(msg)), h.Sum(msg)) {
+			t.Errorf("Hash (bits: %v) Iterate doesn't match Sum", bits)
+		}
+	}
+
+	for _, bits := range []int{8, 20, 40} {
+		if _, err := NewMDHash(bits); err == nil {
+			t.Errorf("Creating %v bit hash should fail", bits)
+		}
+	}
+}
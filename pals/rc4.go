@@ -0,0 +1,46 @@
+package pals
+
+import (
+	"crypto/cipher"
+	"fmt"
+)
+
+type rc4 struct {
+	s    [256]byte
+	i, j uint8
+}
+
+// NewRC4 returns an RC4 keystream generator for the given key, which must
+// be between 1 and 256 bytes long
+func NewRC4(key []byte) (cipher.Stream, error) {
+	if len(key) < 1 || len(key) > 256 {
+		return nil, fmt.Errorf("invalid RC4 key size %v", len(key))
+	}
+	c := &rc4{}
+	for i := range c.s {
+		c.s[i] = byte(i)
+	}
+	// Key scheduling algorithm
+	var j uint8
+	for i := range c.s {
+		j += c.s[i] + key[i%len(key)]
+		c.s[i], c.s[j] = c.s[j], c.s[i]
+	}
+	return c, nil
+}
+
+// XORKeyStream XORs each byte in src with the next byte of the keystream
+// and writes the result to dst. Dst and src must overlap entirely or not at all.
+func (c *rc4) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("output smaller than input")
+	}
+	i, j := c.i, c.j
+	for k, v := range src {
+		i++
+		j += c.s[i]
+		c.s[i], c.s[j] = c.s[j], c.s[i]
+		dst[k] = v ^ c.s[c.s[i]+c.s[j]]
+	}
+	c.i, c.j = i, j
+}
@@ -0,0 +1,43 @@
+package pals
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestRC4(t *testing.T) {
+	// Test vectors from https://en.wikipedia.org/wiki/RC4#Test_vectors
+	ex := []struct {
+		key      string
+		input    string
+		expected string
+	}{
+		{"Key", "Plaintext", "bbf316e8d940af0ad3"},
+		{"Wiki", "pedia", "1021bf0420"},
+		{"Secret", "Attack at dawn", "45a01f645fc35b383552544b9bf5"},
+	}
+
+	for _, e := range ex {
+		c, err := NewRC4([]byte(e.key))
+		if err != nil {
+			t.Fatalf("failed to create RC4 with key %q: %v", e.key, err)
+		}
+		// encrypt in two chunks to check the keystream state carries over
+		result := make([]byte, len(e.input))
+		c.XORKeyStream(result[:3], []byte(e.input[:3]))
+		c.XORKeyStream(result[3:], []byte(e.input[3:]))
+		if hex.EncodeToString(result) != e.expected {
+			t.Errorf("RC4 (key: %q) of %q failed: \nExp: %v \nGot: %x", e.key, e.input, e.expected, result)
+		}
+
+		d, _ := NewRC4([]byte(e.key))
+		d.XORKeyStream(result, result)
+		if string(result) != e.input {
+			t.Errorf("RC4 (key: %q) decryption failed: \nExp: %v \nGot: %v", e.key, e.input, result)
+		}
+	}
+
+	if _, err := NewRC4(nil); err == nil {
+		t.Errorf("Creating RC4 with empty key should fail")
+	}
+}
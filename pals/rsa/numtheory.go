@@ -0,0 +1,67 @@
+package rsa
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// CRT solves the system of congruences x = residues[i] mod moduli[i]
+// using the Chinese Remainder Theorem. The moduli must be pairwise coprime.
+// The unique solution x in [0, M) is returned together with
+// M, the product of the moduli.
+func CRT(residues, moduli []*big.Int) (x, m *big.Int, err error) {
+	if len(residues) != len(moduli) {
+		return nil, nil, fmt.Errorf("got %v residues but %v moduli", len(residues), len(moduli))
+	}
+	if len(moduli) == 0 {
+		return nil, nil, fmt.Errorf("need at least one congruence")
+	}
+
+	m = big.NewInt(1)
+	for _, n := range moduli {
+		m.Mul(m, n)
+	}
+
+	// x = sum(r_i * M_i * invmod(M_i, n_i)) mod M, where M_i = M / n_i
+	x = new(big.Int)
+	for i, n := range moduli {
+		mi := new(big.Int).Quo(m, n)
+		inv, err := InvMod(mi, n)
+		if err != nil {
+			return nil, nil, fmt.Errorf("moduli are not pairwise coprime: %v", err)
+		}
+		term := new(big.Int).Mul(residues[i], mi)
+		term.Mul(term, inv)
+		x.Add(x, term)
+	}
+	return x.Mod(x, m), m, nil
+}
+
+// IntegerNthRoot returns the largest integer r such that r^n <= x, together
+// with whether the root is exact, i.e. r^n == x. x must be non-negative.
+func IntegerNthRoot(x *big.Int, n int) (root *big.Int, exact bool) {
+	if x.Sign() < 0 || n < 1 {
+		panic("IntegerNthRoot: x must be non-negative and n positive")
+	}
+	if x.Sign() == 0 || n == 1 {
+		return new(big.Int).Set(x), true
+	}
+
+	// Newton's method starting from a guess that is guaranteed to be
+	// at least the root: 2^ceil(bitlen(x) / n).
+	bigN := big.NewInt(int64(n))
+	bigNm1 := big.NewInt(int64(n - 1))
+	r := new(big.Int).Lsh(bigOne, uint((x.BitLen()+n-1)/n))
+	for {
+		// next = ((n-1)*r + x / r^(n-1)) / n
+		next := new(big.Int).Exp(r, bigNm1, nil)
+		next.Quo(x, next)
+		next.Add(next, new(big.Int).Mul(bigNm1, r))
+		next.Quo(next, bigN)
+		if next.Cmp(r) >= 0 {
+			break
+		}
+		r = next
+	}
+	return r, new(big.Int).Exp(r, bigN, nil).Cmp(x) == 0
+}
@@ -0,0 +1,63 @@
+package rsa
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCRT(t *testing.T) {
+	ex := []struct {
+		residues []int64
+		moduli   []int64
+		expected int64
+	}{
+		{[]int64{2, 3, 2}, []int64{3, 5, 7}, 23},
+		{[]int64{0, 3, 4}, []int64{3, 4, 5}, 39},
+		{[]int64{5}, []int64{11}, 5},
+	}
+	for _, e := range ex {
+		var residues, moduli []*big.Int
+		for i := range e.residues {
+			residues = append(residues, big.NewInt(e.residues[i]))
+			moduli = append(moduli, big.NewInt(e.moduli[i]))
+		}
+		result, _, err := CRT(residues, moduli)
+		if err != nil {
+			t.Errorf("CRT(%v, %v) failed: %v", e.residues, e.moduli, err)
+			continue
+		}
+		if result.Int64() != e.expected {
+			t.Errorf("CRT(%v, %v) failed: Expected: %v Got: %v", e.residues, e.moduli, e.expected, result)
+		}
+	}
+
+	if _, _, err := CRT([]*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(4), big.NewInt(6)}); err == nil {
+		t.Errorf("CRT with non-coprime moduli should fail")
+	}
+}
+
+func TestIntegerNthRoot(t *testing.T) {
+	big1 := new(big.Int).Exp(big.NewInt(12345678901234567), big.NewInt(3), nil)
+	ex := []struct {
+		x        *big.Int
+		n        int
+		expected *big.Int
+		exact    bool
+	}{
+		{big.NewInt(0), 3, big.NewInt(0), true},
+		{big.NewInt(1), 3, big.NewInt(1), true},
+		{big.NewInt(27), 3, big.NewInt(3), true},
+		{big.NewInt(28), 3, big.NewInt(3), false},
+		{big.NewInt(26), 3, big.NewInt(2), false},
+		{big.NewInt(1024), 10, big.NewInt(2), true},
+		{big.NewInt(99), 2, big.NewInt(9), false},
+		{big1, 3, big.NewInt(12345678901234567), true},
+		{new(big.Int).Sub(big1, big.NewInt(1)), 3, big.NewInt(12345678901234566), false},
+	}
+	for _, e := range ex {
+		result, exact := IntegerNthRoot(e.x, e.n)
+		if result.Cmp(e.expected) != 0 || exact != e.exact {
+			t.Errorf("IntegerNthRoot(%v, %v) failed: Expected: %v (exact: %v) Got: %v (exact: %v)", e.x, e.n, e.expected, e.exact, result, exact)
+		}
+	}
+}
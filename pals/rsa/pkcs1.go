@@ -0,0 +1,188 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// DigestInfoPrefix maps hash functions to the DER encoded ASN.1 DigestInfo
+// header that precedes the digest in a PKCS#1 v1.5 signature (RFC8017 §9.2)
+var DigestInfoPrefix = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+}
+
+// ErrVerification is returned when a signature fails to verify
+var ErrVerification = errors.New("signature verification failed")
+
+// SignPKCS1v15 signs the given digest, which must have been produced by
+// the given hash function, using PKCS#1 v1.5 (EMSA-PKCS1-v1_5) encoding
+func SignPKCS1v15(priv *PrivateKey, hash crypto.Hash, hashed []byte) ([]byte, error) {
+	em, err := encodePKCS1v15(hash, hashed, priv.Size())
+	if err != nil {
+		return nil, err
+	}
+	return I2OSP(priv.Decrypt(OS2IP(em)), priv.Size())
+}
+
+// VerifyPKCS1v15 correctly verifies a PKCS#1 v1.5 signature of the given digest
+func VerifyPKCS1v15(pub *PublicKey, hash crypto.Hash, hashed, sig []byte) error {
+	return PKCS1v15Verifier{}.Verify(pub, hash, hashed, sig)
+}
+
+// PKCS1v15Verifier verifies PKCS#1 v1.5 signatures.
+//
+// If Sloppy is set, the verifier parses the encoded message from the left
+// and doesn't check that the digest is right-justified, i.e. that the 0xff
+// padding fills up the entire remaining space. Any bytes after the digest
+// are ignored. This is the bug behind Bleichenbacher's e=3 forgery.
+type PKCS1v15Verifier struct {
+	Sloppy bool
+}
+
+// Verify verifies the signature sig of the given digest
+func (v PKCS1v15Verifier) Verify(pub *PublicKey, hash crypto.Hash, hashed, sig []byte) error {
+	prefix, ok := DigestInfoPrefix[hash]
+	if !ok {
+		return fmt.Errorf("unsupported hash function %v", hash)
+	}
+	if len(hashed) != hash.Size() {
+		return fmt.Errorf("digest has length %v, expected %v for %v", len(hashed), hash.Size(), hash)
+	}
+	k := pub.Size()
+	if len(sig) != k {
+		return ErrVerification
+	}
+	s := OS2IP(sig)
+	if s.Cmp(pub.N) >= 0 {
+		return ErrVerification
+	}
+	em, err := I2OSP(pub.Encrypt(s), k)
+	if err != nil {
+		return ErrVerification
+	}
+
+	if !v.Sloppy {
+		expected, err := encodePKCS1v15(hash, hashed, k)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(em, expected) {
+			return ErrVerification
+		}
+		return nil
+	}
+
+	// 00 01 FF .. FF 00 ASN.1 HASH, scanned left to right
+	if em[0] != 0x00 || em[1] != 0x01 {
+		return ErrVerification
+	}
+	i := 2
+	for i < len(em) && em[i] == 0xff {
+		i++
+	}
+	if i == 2 || i >= len(em) || em[i] != 0x00 {
+		return ErrVerification
+	}
+	rest := em[i+1:]
+	if !bytes.HasPrefix(rest, prefix) {
+		return ErrVerification
+	}
+	rest = rest[len(prefix):]
+	if len(rest) < len(hashed) || !bytes.Equal(rest[:len(hashed)], hashed) {
+		return ErrVerification
+	}
+	return nil
+}
+
+// encodePKCS1v15 creates the k byte encoded message
+// 00 01 FF .. FF 00 ASN.1 HASH for the given digest
+func encodePKCS1v15(hash crypto.Hash, hashed []byte, k int) ([]byte, error) {
+	prefix, ok := DigestInfoPrefix[hash]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash function %v", hash)
+	}
+	if len(hashed) != hash.Size() {
+		return nil, fmt.Errorf("digest has length %v, expected %v for %v", len(hashed), hash.Size(), hash)
+	}
+	// need at least 8 bytes of padding as per RFC8017
+	tLen := len(prefix) + len(hashed)
+	if k < tLen+11 {
+		return nil, fmt.Errorf("modulus too short for %v signature", hash)
+	}
+
+	em := make([]byte, k)
+	em[1] = 0x01
+	for i := 2; i < k-tLen-1; i++ {
+		em[i] = 0xff
+	}
+	copy(em[k-tLen:], prefix)
+	copy(em[k-len(hashed):], hashed)
+	return em, nil
+}
+
+// ErrDecryption is returned when a cyphertext doesn't decrypt to
+// a properly padded message
+var ErrDecryption = errors.New("decryption error")
+
+// PadPKCS1v15 pads msg to k bytes using PKCS#1 v1.5 encryption padding
+// (block type 2): 00 02 PS 00 M, where PS are at least 8 random non-zero bytes
+func PadPKCS1v15(msg []byte, k int) ([]byte, error) {
+	if len(msg) > k-11 {
+		return nil, fmt.Errorf("message too long: %v bytes, at most %v allowed", len(msg), k-11)
+	}
+	em := make([]byte, k)
+	em[1] = 0x02
+	ps := em[2 : k-len(msg)-1]
+	if _, err := rand.Read(ps); err != nil {
+		return nil, err
+	}
+	// padding bytes must be non-zero, so redraw any zeroes
+	for i := range ps {
+		for ps[i] == 0 {
+			if _, err := rand.Read(ps[i : i+1]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	copy(em[k-len(msg):], msg)
+	return em, nil
+}
+
+// UnpadPKCS1v15 removes PKCS#1 v1.5 encryption padding from the
+// encoded message em
+func UnpadPKCS1v15(em []byte) ([]byte, error) {
+	if len(em) < 11 || em[0] != 0x00 || em[1] != 0x02 {
+		return nil, ErrDecryption
+	}
+	sep := bytes.IndexByte(em[2:], 0x00)
+	if sep < 8 {
+		return nil, ErrDecryption
+	}
+	return em[2+sep+1:], nil
+}
+
+// EncryptPKCS1v15 pads msg with PKCS#1 v1.5 encryption padding and encrypts it
+func EncryptPKCS1v15(pub *PublicKey, msg []byte) ([]byte, error) {
+	em, err := PadPKCS1v15(msg, pub.Size())
+	if err != nil {
+		return nil, err
+	}
+	return I2OSP(pub.Encrypt(OS2IP(em)), pub.Size())
+}
+
+// DecryptPKCS1v15 decrypts crypt and removes its PKCS#1 v1.5 encryption padding
+func DecryptPKCS1v15(priv *PrivateKey, crypt []byte) ([]byte, error) {
+	c := OS2IP(crypt)
+	if len(crypt) != priv.Size() || c.Cmp(priv.N) >= 0 {
+		return nil, ErrDecryption
+	}
+	em, err := I2OSP(priv.Decrypt(c), priv.Size())
+	if err != nil {
+		return nil, ErrDecryption
+	}
+	return UnpadPKCS1v15(em)
+}
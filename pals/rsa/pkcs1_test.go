@@ -0,0 +1,105 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha1"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestSignVerifyPKCS1v15(t *testing.T) {
+	msg := []byte("hi mom")
+	sha1Sum := sha1.Sum(msg)
+	sha256Sum := sha256.Sum256(msg)
+	ex := []struct {
+		bits   int
+		hash   crypto.Hash
+		hashed []byte
+	}{
+		{1024, crypto.SHA1, sha1Sum[:]},
+		{1024, crypto.SHA256, sha256Sum[:]},
+	}
+
+	for _, e := range ex {
+		priv, err := GenerateKey(e.bits, 3)
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		sig, err := SignPKCS1v15(priv, e.hash, e.hashed)
+		if err != nil {
+			t.Fatalf("failed to sign with %v: %v", e.hash, err)
+		}
+		for _, v := range []PKCS1v15Verifier{{Sloppy: false}, {Sloppy: true}} {
+			if err := v.Verify(&priv.PublicKey, e.hash, e.hashed, sig); err != nil {
+				t.Errorf("Verification (hash: %v, sloppy: %v) of valid signature failed: %v", e.hash, v.Sloppy, err)
+			}
+		}
+
+		// A truncated digest matches a prefix of the real one, and must
+		// not verify
+		for _, v := range []PKCS1v15Verifier{{Sloppy: false}, {Sloppy: true}} {
+			for _, n := range []int{0, 4} {
+				if err := v.Verify(&priv.PublicKey, e.hash, e.hashed[:n], sig); err == nil {
+					t.Errorf("Verification (hash: %v, sloppy: %v) of %v byte digest succeeded", e.hash, v.Sloppy, n)
+				}
+			}
+		}
+
+		// Tampering with the signature must be detected
+		sig[len(sig)-1] ^= 1
+		for _, v := range []PKCS1v15Verifier{{Sloppy: false}, {Sloppy: true}} {
+			if err := v.Verify(&priv.PublicKey, e.hash, e.hashed, sig); err == nil {
+				t.Errorf("Verification (hash: %v, sloppy: %v) of tampered signature succeeded", e.hash, v.Sloppy)
+			}
+		}
+	}
+}
+
+func TestEncryptDecryptPKCS1v15(t *testing.T) {
+	ex := []struct {
+		bits  int
+		input []byte
+	}{
+		{256, []byte("kick it, CC")},
+		{256, []byte("")},
+		{768, []byte("We all live in a yellow submarine")},
+	}
+
+	for _, e := range ex {
+		priv, err := GenerateKey(e.bits, 3)
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		crypt, err := EncryptPKCS1v15(&priv.PublicKey, e.input)
+		if err != nil {
+			t.Fatalf("failed to encrypt %v: %v", e.input, err)
+		}
+		result, err := DecryptPKCS1v15(priv, crypt)
+		if err != nil {
+			t.Fatalf("failed to decrypt %v: %v", crypt, err)
+		}
+		if !bytes.Equal(result, e.input) {
+			t.Errorf("Encrypt-decrypt (bits: %v) failed: \nInp: %v \nGot: %v", e.bits, e.input, result)
+		}
+	}
+}
+
+func TestUnpadPKCS1v15(t *testing.T) {
+	ex := []struct {
+		input []byte
+		valid bool
+	}{
+		{[]byte("\x00\x02\x01\x02\x03\x04\x05\x06\x07\x08\x00hi"), true},
+		{[]byte("\x00\x02\x01\x02\x03\x04\x05\x06\x07\x08\x00"), true},
+		{[]byte("\x00\x01\x01\x02\x03\x04\x05\x06\x07\x08\x00hi"), false},
+		{[]byte("\x01\x02\x01\x02\x03\x04\x05\x06\x07\x08\x00hi"), false},
+		{[]byte("\x00\x02\x01\x02\x03\x04\x05\x06\x07\x00\x00hi"), false},
+		{[]byte("\x00\x02\x01\x02\x03\x04\x05\x06\x07\x08\x09hi"), false},
+	}
+	for _, e := range ex {
+		if _, err := UnpadPKCS1v15(e.input); (err == nil) != e.valid {
+			t.Errorf("Unpad of %v failed: Expected valid: %v Got: %v", e.input, e.valid, err)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package rsa implements textbook RSA, i.e. RSA without any padding.
+// It is deliberately insecure so that the classic attacks against
+// RSA can be demonstrated against it.
+package rsa
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+var bigOne = big.NewInt(1)
+
+// PublicKey represents a textbook RSA public key
+type PublicKey struct {
+	N *big.Int // modulus
+	E *big.Int // public exponent
+}
+
+// PrivateKey represents a textbook RSA private key
+type PrivateKey struct {
+	PublicKey
+	D *big.Int // private exponent
+
+	// Primes holds the factors p and q of N, if known. They are only
+	// used to speed up decryption via the CRT.
+	Primes []*big.Int
+}
+
+// GeneratePrime returns a random prime of the given bit length
+func GeneratePrime(bits int) (*big.Int, error) {
+	return rand.Prime(rand.Reader, bits)
+}
+
+// EGCD runs the extended Euclidean algorithm on a and b,
+// returning g = gcd(a, b) and the Bézout coefficients x and y
+// such that a*x + b*y = g.
+func EGCD(a, b *big.Int) (g, x, y *big.Int) {
+	// Invariants: oldR = a*oldX + b*oldY and r = a*x + b*y
+	oldR, r := new(big.Int).Set(a), new(big.Int).Set(b)
+	oldX, x := big.NewInt(1), big.NewInt(0)
+	oldY, y := big.NewInt(0), big.NewInt(1)
+
+	for r.Sign() != 0 {
+		q := new(big.Int).Quo(oldR, r)
+		oldR, r = r, new(big.Int).Sub(oldR, new(big.Int).Mul(q, r))
+		oldX, x = x, new(big.Int).Sub(oldX, new(big.Int).Mul(q, x))
+		oldY, y = y, new(big.Int).Sub(oldY, new(big.Int).Mul(q, y))
+	}
+	return oldR, oldX, oldY
+}
+
+// InvMod returns the inverse of a modulo m, i.e. the number x in [0, m)
+// such that a*x = 1 mod m. An error is returned if a is not invertible.
+func InvMod(a, m *big.Int) (*big.Int, error) {
+	g, x, _ := EGCD(new(big.Int).Mod(a, m), m)
+	if g.Cmp(bigOne) != 0 {
+		return nil, fmt.Errorf("%v is not invertible mod %v: gcd is %v", a, m, g)
+	}
+	return x.Mod(x, m), nil
+}
+
+// GenerateKey generates a textbook RSA key pair with a modulus of the
+// given bit size and public exponent e
+func GenerateKey(bits int, e int) (*PrivateKey, error) {
+	if bits < 16 {
+		return nil, fmt.Errorf("key size %v too small", bits)
+	}
+	bigE := big.NewInt(int64(e))
+	for {
+		p, err := GeneratePrime(bits - bits/2)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate p: %v", err)
+		}
+		q, err := GeneratePrime(bits / 2)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate q: %v", err)
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		n := new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+		// e must be invertible mod totient, otherwise try again
+		totient := new(big.Int).Mul(new(big.Int).Sub(p, bigOne), new(big.Int).Sub(q, bigOne))
+		d, err := InvMod(bigE, totient)
+		if err != nil {
+			continue
+		}
+		return &PrivateKey{
+			PublicKey: PublicKey{N: n, E: bigE},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}, nil
+	}
+}
+
+// Size returns the size of the modulus in bytes
+func (pub *PublicKey) Size() int {
+	return (pub.N.BitLen() + 7) / 8
+}
+
+// Encrypt encrypts the message m, returning m^e mod N
+func (pub *PublicKey) Encrypt(m *big.Int) *big.Int {
+	return new(big.Int).Exp(m, pub.E, pub.N)
+}
+
+// Decrypt decrypts the cyphertext c, returning c^d mod N
+func (priv *PrivateKey) Decrypt(c *big.Int) *big.Int {
+	if len(priv.Primes) != 2 {
+		return new(big.Int).Exp(c, priv.D, priv.N)
+	}
+
+	// Garner's method: m = mq + q * ((mp - mq) * q^-1 mod p)
+	p, q := priv.Primes[0], priv.Primes[1]
+	mp := new(big.Int).Exp(c, new(big.Int).Mod(priv.D, new(big.Int).Sub(p, bigOne)), p)
+	mq := new(big.Int).Exp(c, new(big.Int).Mod(priv.D, new(big.Int).Sub(q, bigOne)), q)
+	h := new(big.Int).Sub(mp, mq)
+	h.Mul(h, new(big.Int).ModInverse(q, p))
+	h.Mod(h, p)
+	return h.Mul(h, q).Add(h, mq)
+}
+
+// EncryptBytes encrypts the given message, which must be numerically smaller
+// than the modulus. The cyphertext is returned as Size() bytes.
+func (pub *PublicKey) EncryptBytes(msg []byte) ([]byte, error) {
+	m := OS2IP(msg)
+	if m.Cmp(pub.N) >= 0 {
+		return nil, fmt.Errorf("message too long for %v bit modulus", pub.N.BitLen())
+	}
+	return I2OSP(pub.Encrypt(m), pub.Size())
+}
+
+// DecryptBytes decrypts the given cyphertext. Since textbook RSA has no
+// padding, leading zero bytes of the original message are not recovered.
+func (priv *PrivateKey) DecryptBytes(crypt []byte) ([]byte, error) {
+	c := OS2IP(crypt)
+	if c.Cmp(priv.N) >= 0 {
+		return nil, fmt.Errorf("cyphertext out of range for %v bit modulus", priv.N.BitLen())
+	}
+	return priv.Decrypt(c).Bytes(), nil
+}
+
+// I2OSP converts a non-negative integer into a big-endian byte slice of
+// length n, as per RFC8017. An error is returned if x does not fit.
+func I2OSP(x *big.Int, n int) ([]byte, error) {
+	if x.Sign() < 0 {
+		return nil, fmt.Errorf("cannot convert negative integer %v", x)
+	}
+	b := x.Bytes()
+	if len(b) > n {
+		return nil, fmt.Errorf("integer too large: needs %v bytes, have %v", len(b), n)
+	}
+	out := make([]byte, n)
+	copy(out[n-len(b):], b)
+	return out, nil
+}
+
+// OS2IP converts a big-endian byte slice into a non-negative integer,
+// as per RFC8017.
+func OS2IP(b []byte) *big.Int {
+	return new(big.Int).SetBytes(b)
+}
@@ -0,0 +1,115 @@
+package rsa
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestInvMod(t *testing.T) {
+	ex := []struct {
+		a, m, expected int64
+	}{
+		{17, 3120, 2753},
+		{3, 11, 4},
+		{1, 7, 1},
+		{-3, 11, 7},
+	}
+	for _, e := range ex {
+		result, err := InvMod(big.NewInt(e.a), big.NewInt(e.m))
+		if err != nil {
+			t.Errorf("InvMod(%v, %v) failed: %v", e.a, e.m, err)
+			continue
+		}
+		if result.Cmp(big.NewInt(e.expected)) != 0 {
+			t.Errorf("InvMod(%v, %v) failed: Expected: %v Got: %v", e.a, e.m, e.expected, result)
+		}
+	}
+
+	if _, err := InvMod(big.NewInt(6), big.NewInt(9)); err == nil {
+		t.Errorf("InvMod(6, 9) should fail since gcd is 3")
+	}
+}
+
+func TestEGCD(t *testing.T) {
+	ex := []struct {
+		a, b, g int64
+	}{
+		{240, 46, 2},
+		{17, 3120, 1},
+		{12, 0, 12},
+	}
+	for _, e := range ex {
+		a, b := big.NewInt(e.a), big.NewInt(e.b)
+		g, x, y := EGCD(a, b)
+		if g.Int64() != e.g {
+			t.Errorf("EGCD(%v, %v) gcd failed: Expected: %v Got: %v", e.a, e.b, e.g, g)
+		}
+		// Check Bézout identity
+		lhs := new(big.Int).Add(new(big.Int).Mul(a, x), new(big.Int).Mul(b, y))
+		if lhs.Cmp(g) != 0 {
+			t.Errorf("EGCD(%v, %v) coefficients %v, %v don't satisfy a*x + b*y = %v", e.a, e.b, x, y, g)
+		}
+	}
+}
+
+func TestEncryptDecryptRSA(t *testing.T) {
+	ex := []struct {
+		bits  int
+		e     int
+		input []byte
+	}{
+		{256, 3, []byte("YELLOW SUBMARINE")},
+		{512, 3, []byte("We all live in a yellow submarine")},
+		{1024, 65537, []byte("Cause we're having a good time..")},
+	}
+
+	for _, e := range ex {
+		priv, err := GenerateKey(e.bits, e.e)
+		if err != nil {
+			t.Fatalf("failed to generate %v bit key: %v", e.bits, err)
+		}
+		if priv.N.BitLen() != e.bits {
+			t.Errorf("Generated modulus has wrong size: Expected: %v Got: %v", e.bits, priv.N.BitLen())
+		}
+		crypt, err := priv.EncryptBytes(e.input)
+		if err != nil {
+			t.Fatalf("failed to encrypt %v: %v", e.input, err)
+		}
+		result, err := priv.DecryptBytes(crypt)
+		if err != nil {
+			t.Fatalf("failed to decrypt %v: %v", crypt, err)
+		}
+		if !bytes.Equal(result, e.input) {
+			t.Errorf("Encrypt-decrypt (bits: %v, e: %v) failed: \nInp: %v \nGot: %v", e.bits, e.e, e.input, result)
+		}
+	}
+}
+
+func TestI2OSP(t *testing.T) {
+	ex := []struct {
+		x        int64
+		n        int
+		expected []byte
+	}{
+		{0, 2, []byte("\x00\x00")},
+		{1, 2, []byte("\x00\x01")},
+		{256, 3, []byte("\x00\x01\x00")},
+	}
+	for _, e := range ex {
+		result, err := I2OSP(big.NewInt(e.x), e.n)
+		if err != nil {
+			t.Errorf("I2OSP(%v, %v) failed: %v", e.x, e.n, err)
+		}
+		if !bytes.Equal(result, e.expected) {
+			t.Errorf("I2OSP(%v, %v) failed: Expected: %v Got: %v", e.x, e.n, e.expected, result)
+		}
+		if OS2IP(result).Int64() != e.x {
+			t.Errorf("OS2IP(%v) failed: Expected: %v Got: %v", result, e.x, OS2IP(result))
+		}
+	}
+
+	if _, err := I2OSP(big.NewInt(256), 1); err == nil {
+		t.Errorf("I2OSP(256, 1) should fail")
+	}
+}
@@ -24,8 +24,23 @@ const (
 	CBCMode CryptMode = iota
 	// ECBMode is Electronic Code Book (ECB) mode
 	ECBMode
+	// CTRMode is Counter (CTR) mode
+	CTRMode
 )
 
+// String returns the name of the mode
+func (m CryptMode) String() string {
+	switch m {
+	case CBCMode:
+		return "CBC"
+	case ECBMode:
+		return "ECB"
+	case CTRMode:
+		return "CTR"
+	}
+	return fmt.Sprintf("CryptMode(%d)", byte(m))
+}
+
 // AES key size used throughout
 const keySize = 16
 
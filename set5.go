@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ExalDraen/cryptopals-challenges/pals/rsa"
+)
+
+// Set5 solutions
+func Set5() {
+	C39()
+	C40()
+}
+
+// C39 solution
+func C39() {
+	fmt.Println("---------------------- c39 ------------------------")
+	const msg = "YELLOW SUBMARINE"
+
+	priv, err := rsa.GenerateKey(1024, 3)
+	if err != nil {
+		log.Fatalf("failed to generate RSA key: %v", err)
+	}
+	crypt, err := priv.EncryptBytes([]byte(msg))
+	if err != nil {
+		log.Fatalf("failed to encrypt %v: %v", msg, err)
+	}
+	fmt.Printf("Encrypted %q to %x\n", msg, crypt)
+
+	plain, err := priv.DecryptBytes(crypt)
+	if err != nil {
+		log.Fatalf("failed to decrypt %x: %v", crypt, err)
+	}
+	fmt.Printf("Decrypted back to %q\n", plain)
+}
@@ -0,0 +1,13 @@
+package main
+
+// Set6 solutions
+func Set6() {
+	C41()
+	C42()
+	C43()
+	C44()
+	C45()
+	C46()
+	C47()
+	C48()
+}
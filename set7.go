@@ -0,0 +1,12 @@
+package main
+
+// Set7 solutions
+func Set7() {
+	C49()
+	C50()
+	C51()
+	C52()
+	C53()
+	C54()
+	C56()
+}
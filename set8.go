@@ -0,0 +1,13 @@
+package main
+
+// Set8 solutions
+func Set8() {
+	C57()
+	C58()
+	C59()
+	C60()
+	C61()
+	C62()
+	C63()
+	C64()
+}